@@ -4,6 +4,8 @@ import (
 	"errors"
 	"net/http"
 	"time"
+
+	"github.com/go-resty/resty/v2"
 )
 
 type Api struct {
@@ -140,6 +142,34 @@ func WithTransport(rt http.RoundTripper) ClientOption {
 	}
 }
 
+// WithRetryOnRateLimit returns a ClientOption retrying the requests failing with a 429 status code (too many requests),
+// according to the WithRetryCount and WithRetryTimeout options. Disabled by default.
+func WithRetryOnRateLimit(retry bool) ClientOption {
+	return func(c *Api) error {
+		c.httpClient.retryTooManyRequests = retry
+		return nil
+	}
+}
+
+// WithRateLimit returns a ClientOption limiting the number of requests per second sent by the client.
+// The limit is shared by all the services of the client and applies to retries and streamed uploads as well.
+// The API allows 6 requests per second per token.
+func WithRateLimit(requestsPerSecond int) ClientOption {
+	return func(c *Api) error {
+		if requestsPerSecond <= 0 {
+			return errors.New("lokalise: rate limit must be positive")
+		}
+		l := newRateLimiter(requestsPerSecond)
+		if c.httpClient.limiter == nil {
+			c.httpClient.Client.OnBeforeRequest(func(_ *resty.Client, r *resty.Request) error {
+				return c.httpClient.limiter.wait(r.Context())
+			})
+		}
+		c.httpClient.limiter = l
+		return nil
+	}
+}
+
 func WithPageLimit(limit uint) ClientOption {
 	return func(c *Api) error {
 		c.pageOptions.Limit = limit
@@ -0,0 +1,379 @@
+package lokalise
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultMaxBundleSize = 512 << 20
+	defaultMaxFileSize   = 256 << 20
+	defaultMaxTotalSize  = 1 << 30
+	defaultMaxFiles      = 10000
+)
+
+var (
+	// ErrBundleTooLarge is returned when a bundle exceeds the limits of BundleOptions.
+	ErrBundleTooLarge = errors.New("lokalise: bundle too large")
+	// ErrUnsafeBundleEntry is returned for bundle entries escaping the target directory,
+	// symbolic links and other non regular files.
+	ErrUnsafeBundleEntry = errors.New("lokalise: unsafe bundle entry")
+	// ErrBundleConflict is returned with BundleFailOnConflict when a bundle file already exists.
+	ErrBundleConflict = errors.New("lokalise: bundle file already exists")
+)
+
+// BundlePolicy tells how bundle files are written over existing files.
+type BundlePolicy int
+
+const (
+	// BundleOverwrite replaces existing files, other files of the directory are kept.
+	BundleOverwrite BundlePolicy = iota
+	// BundleKeepExisting only writes the files which do not exist yet.
+	BundleKeepExisting
+	// BundleFailOnConflict extracts nothing if any of the files exists.
+	BundleFailOnConflict
+)
+
+// isoLikeRegexp matches language codes such as en, pt_BR, zh-Hans or sr-Latn-RS. Three letter codes
+// are left out as they are too close to common file and directory names.
+var isoLikeRegexp = regexp.MustCompile(`^[a-z]{2}(?:[_-][A-Za-z0-9]{2,4})*$`)
+
+// androidRegionRegexp matches the region qualifier of Android resource directories, i.e. pt-rBR.
+var androidRegionRegexp = regexp.MustCompile(`^([a-z]{2,3})-r([A-Z]{2})$`)
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service request/response objects
+// _____________________________________________________________________________________________________________________
+
+type BundleOptions struct {
+	// MaxBundleSize limits the size of the downloaded zip. Default: 512 MiB
+	MaxBundleSize int64
+	// MaxFileSize limits the extracted size of every file. Default: 256 MiB
+	MaxFileSize int64
+	// MaxTotalSize limits the extracted size of all files. Default: 1 GiB
+	MaxTotalSize int64
+	// MaxFiles limits the number of files. Default: 10000
+	MaxFiles int
+	Policy   BundlePolicy
+	// Languages are the language ISO codes files are attributed to. If empty, any path part
+	// looking like a language code is used, see BundleFile.LangISO.
+	Languages []string
+}
+
+type BundleFile struct {
+	// Path is relative to the target directory, with slashes.
+	Path string
+	// LangISO is taken from the file name without extension, i.e. "locale/de.json", or from a directory,
+	// i.e. "de/app.json", "de.lproj/Localizable.strings" or "values-pt-rBR/strings.xml" (pt_BR). Empty if unknown.
+	LangISO string
+	Size    int64
+}
+
+type BundleResult struct {
+	// Written are the extracted files, sorted by path.
+	Written []BundleFile
+	// Kept are the files left as is with BundleKeepExisting.
+	Kept []BundleFile
+}
+
+// ByLanguage returns the paths of the written files per language ISO code.
+func (r BundleResult) ByLanguage() map[string][]string {
+	m := make(map[string][]string)
+	for _, f := range r.Written {
+		m[f.LangISO] = append(m[f.LangISO], f.Path)
+	}
+	return m
+}
+
+func (o *BundleOptions) applyDefaults() {
+	if o.MaxBundleSize <= 0 {
+		o.MaxBundleSize = defaultMaxBundleSize
+	}
+	if o.MaxFileSize <= 0 {
+		o.MaxFileSize = defaultMaxFileSize
+	}
+	if o.MaxTotalSize <= 0 {
+		o.MaxTotalSize = defaultMaxTotalSize
+	}
+	if o.MaxFiles <= 0 {
+		o.MaxFiles = defaultMaxFiles
+	}
+}
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service methods
+// _____________________________________________________________________________________________________________________
+
+// FetchBundle downloads a bundle, i.e. FileDownloadResponse.BundleURL, into w and returns its size.
+// ErrBundleTooLarge is returned once more than maxSize bytes are read, a zero maxSize means no limit.
+// The plain HTTP client is used so that the API token is not sent to the storage.
+func (c *FileService) FetchBundle(bundleURL string, w io.Writer, maxSize int64) (n int64, err error) {
+	req, err := http.NewRequestWithContext(c.Ctx(), http.MethodGet, bundleURL, nil)
+	if err != nil {
+		return
+	}
+	res, err := c.GetClient().Do(req)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("lokalise: fetch bundle: unexpected status %s", res.Status)
+	}
+
+	if maxSize <= 0 {
+		return io.Copy(w, res.Body)
+	}
+	if res.ContentLength > maxSize {
+		return 0, fmt.Errorf("%w: %d bytes, limit is %d", ErrBundleTooLarge, res.ContentLength, maxSize)
+	}
+	n, err = io.Copy(w, io.LimitReader(res.Body, maxSize+1))
+	if err == nil && n > maxSize {
+		err = fmt.Errorf("%w: more than %d bytes", ErrBundleTooLarge, maxSize)
+	}
+	return
+}
+
+// DownloadBundle downloads a bundle to a temporary file and extracts it into dir, see ExtractBundle.
+func (c *FileService) DownloadBundle(bundleURL, dir string, opts BundleOptions) (r BundleResult, err error) {
+	opts.applyDefaults()
+
+	tmp, err := os.CreateTemp("", "lokalise-bundle-*.zip")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := c.FetchBundle(bundleURL, tmp, opts.MaxBundleSize)
+	if err != nil {
+		return
+	}
+	return ExtractBundleReader(tmp, size, dir, opts)
+}
+
+// ExtractBundle extracts a zip bundle into dir, which is created if needed.
+// Every entry is checked before anything is written: entries escaping dir, symbolic links and other
+// non regular files are rejected with ErrUnsafeBundleEntry, bundles exceeding the limits with ErrBundleTooLarge.
+func ExtractBundle(zipPath, dir string, opts BundleOptions) (r BundleResult, err error) {
+	f, err := os.Open(zipPath)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return
+	}
+	return ExtractBundleReader(f, info.Size(), dir, opts)
+}
+
+// ExtractBundleReader extracts a zip bundle of the given size read from ra, see ExtractBundle.
+func ExtractBundleReader(ra io.ReaderAt, size int64, dir string, opts BundleOptions) (r BundleResult, err error) {
+	opts.applyDefaults()
+	if size > opts.MaxBundleSize {
+		return r, fmt.Errorf("%w: %d bytes, limit is %d", ErrBundleTooLarge, size, opts.MaxBundleSize)
+	}
+
+	zr, err := zip.NewReader(ra, size)
+	if err != nil {
+		return
+	}
+
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return
+	}
+
+	entries, err := checkBundleEntries(zr.File, dir, opts)
+	if err != nil {
+		return
+	}
+
+	var total int64
+	for _, e := range entries {
+		if e.keep {
+			r.Kept = append(r.Kept, e.BundleFile)
+			continue
+		}
+		n, err := extractBundleFile(e.file, filepath.Join(dir, filepath.FromSlash(e.Path)), opts.MaxFileSize, opts.MaxTotalSize-total)
+		if err != nil {
+			return r, err
+		}
+		total += n
+		e.Size = n
+		r.Written = append(r.Written, e.BundleFile)
+	}
+	return r, nil
+}
+
+type bundleEntry struct {
+	BundleFile
+	file *zip.File
+	keep bool
+}
+
+// checkBundleEntries validates the entries of the bundle and returns its files sorted by path.
+func checkBundleEntries(files []*zip.File, dir string, opts BundleOptions) ([]bundleEntry, error) {
+	var (
+		entries  []bundleEntry
+		declared uint64
+	)
+	for _, f := range files {
+		name, err := safeBundlePath(f.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		mode := f.Mode()
+		if mode.IsDir() {
+			continue
+		}
+		if !mode.IsRegular() {
+			return nil, fmt.Errorf("%w: %s is not a regular file", ErrUnsafeBundleEntry, f.Name)
+		}
+
+		if len(entries) == opts.MaxFiles {
+			return nil, fmt.Errorf("%w: more than %d files", ErrBundleTooLarge, opts.MaxFiles)
+		}
+		if f.UncompressedSize64 > uint64(opts.MaxFileSize) {
+			return nil, fmt.Errorf("%w: %s has %d bytes, limit is %d", ErrBundleTooLarge, f.Name, f.UncompressedSize64, opts.MaxFileSize)
+		}
+		declared += f.UncompressedSize64
+		if declared > uint64(opts.MaxTotalSize) {
+			return nil, fmt.Errorf("%w: more than %d bytes extracted", ErrBundleTooLarge, opts.MaxTotalSize)
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := checkNoSymlinks(dir, name); err != nil {
+			return nil, err
+		}
+		_, statErr := os.Lstat(target)
+		exists := statErr == nil
+		if exists && opts.Policy == BundleFailOnConflict {
+			return nil, fmt.Errorf("%w: %s", ErrBundleConflict, name)
+		}
+
+		entries = append(entries, bundleEntry{
+			BundleFile: BundleFile{Path: name, LangISO: bundleLanguage(name, opts.Languages), Size: int64(f.UncompressedSize64)},
+			file:       f,
+			keep:       exists && opts.Policy == BundleKeepExisting,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	for i := 1; i < len(entries); i++ {
+		if entries[i].Path == entries[i-1].Path {
+			return nil, fmt.Errorf("%w: duplicate entry %s", ErrUnsafeBundleEntry, entries[i].Path)
+		}
+	}
+	return entries, nil
+}
+
+// safeBundlePath returns the cleaned slash separated path of an entry, which must stay inside the target directory.
+func safeBundlePath(name string) (string, error) {
+	if name == "" || strings.Contains(name, `\`) || strings.HasPrefix(name, "/") {
+		return "", fmt.Errorf("%w: %q", ErrUnsafeBundleEntry, name)
+	}
+	clean := path.Clean(name)
+	if clean == "." || !filepath.IsLocal(filepath.FromSlash(clean)) {
+		return "", fmt.Errorf("%w: %q", ErrUnsafeBundleEntry, name)
+	}
+	return clean, nil
+}
+
+// checkNoSymlinks rejects existing symbolic links among the parent directories of name inside dir,
+// as they could redirect the file outside of dir.
+func checkNoSymlinks(dir, name string) error {
+	current := dir
+	parts := strings.Split(name, "/")
+	for _, part := range parts[:len(parts)-1] {
+		current = filepath.Join(current, part)
+		info, err := os.Lstat(current)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 || !info.IsDir() {
+			return fmt.Errorf("%w: %s is not a directory", ErrUnsafeBundleEntry, current)
+		}
+	}
+	info, err := os.Lstat(filepath.Join(dir, filepath.FromSlash(name)))
+	if err == nil && !info.Mode().IsRegular() {
+		return fmt.Errorf("%w: %s exists and is not a regular file", ErrUnsafeBundleEntry, name)
+	}
+	return nil
+}
+
+// extractBundleFile writes the entry to a temporary file renamed to target, so that target is never
+// half written. Sizes are checked on the actual content as zip headers can lie.
+func extractBundleFile(f *zip.File, target string, maxSize, remaining int64) (int64, error) {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return 0, err
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".bundle-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	limit := min(maxSize, remaining)
+	n, err := io.Copy(tmp, io.LimitReader(rc, limit+1))
+	if err == nil && n > limit {
+		err = fmt.Errorf("%w: %s is larger than declared", ErrBundleTooLarge, f.Name)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	return n, os.Rename(tmp.Name(), target)
+}
+
+// bundleLanguage attributes a bundle file to a language, see BundleFile.LangISO.
+func bundleLanguage(name string, languages []string) string {
+	parts := strings.Split(name, "/")
+	base := parts[len(parts)-1]
+	candidates := []string{strings.TrimSuffix(base, path.Ext(base))}
+	for i := len(parts) - 2; i >= 0; i-- {
+		dir := strings.TrimSuffix(parts[i], ".lproj")
+		candidates = append(candidates, dir)
+		if android, ok := strings.CutPrefix(dir, "values-"); ok {
+			candidates = append(candidates, androidRegionRegexp.ReplaceAllString(android, "${1}_$2"))
+		}
+	}
+
+	for _, c := range candidates {
+		if len(languages) == 0 {
+			if isoLikeRegexp.MatchString(c) {
+				return c
+			}
+			continue
+		}
+		for _, l := range languages {
+			if c == l {
+				return l
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,210 @@
+package lokalise
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+type zipEntry struct {
+	name    string
+	content string
+	mode    os.FileMode
+}
+
+func buildZip(t *testing.T, entries ...zipEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		h := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		if e.mode != 0 {
+			h.SetMode(e.mode)
+		}
+		w, err := zw.CreateHeader(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(e.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func extractZip(data []byte, dir string, opts BundleOptions) (BundleResult, error) {
+	return ExtractBundleReader(bytes.NewReader(data), int64(len(data)), dir, opts)
+}
+
+func TestExtractBundle(t *testing.T) {
+	data := buildZip(t,
+		zipEntry{name: "locale/"},
+		zipEntry{name: "locale/en.json", content: `{"a":"A"}`},
+		zipEntry{name: "locale/de.json", content: `{"a":"Ä"}`},
+		zipEntry{name: "fr.lproj/Localizable.strings", content: `"a" = "À";`},
+		zipEntry{name: "values-pt-rBR/strings.xml", content: `<resources/>`},
+		zipEntry{name: "README", content: `read me`},
+	)
+	dir := t.TempDir()
+
+	r, err := extractZip(data, dir, BundleOptions{})
+	if err != nil {
+		t.Fatalf("ExtractBundleReader returned error: %v", err)
+	}
+
+	wantLangs := map[string][]string{
+		"":      {"README"},
+		"fr":    {"fr.lproj/Localizable.strings"},
+		"de":    {"locale/de.json"},
+		"en":    {"locale/en.json"},
+		"pt_BR": {"values-pt-rBR/strings.xml"},
+	}
+	if got := r.ByLanguage(); !reflect.DeepEqual(got, wantLangs) {
+		t.Errorf(assertionTemplate, "ByLanguage", got, wantLangs)
+	}
+	if b, _ := os.ReadFile(filepath.Join(dir, "locale", "de.json")); string(b) != `{"a":"Ä"}` {
+		t.Errorf(assertionTemplate, "extracted content", string(b), `{"a":"Ä"}`)
+	}
+
+	// known languages restrict the attribution
+	r, err = extractZip(data, t.TempDir(), BundleOptions{Languages: []string{"en", "de", "pt_BR"}})
+	if err != nil {
+		t.Fatalf("ExtractBundleReader returned error: %v", err)
+	}
+	if got := r.ByLanguage()["pt_BR"]; !reflect.DeepEqual(got, []string{"values-pt-rBR/strings.xml"}) {
+		t.Errorf(assertionTemplate, "ByLanguage with languages", got, "strings.xml")
+	}
+	if got := r.ByLanguage()[""]; !reflect.DeepEqual(got, []string{"README", "fr.lproj/Localizable.strings"}) {
+		t.Errorf(assertionTemplate, "ByLanguage with languages", got, "README and Localizable.strings")
+	}
+}
+
+func TestExtractBundle_Unsafe(t *testing.T) {
+	tests := map[string][]byte{
+		"traversal":      buildZip(t, zipEntry{name: "../evil.json", content: "x"}),
+		"nested":         buildZip(t, zipEntry{name: "locale/../../evil.json", content: "x"}),
+		"absolute":       buildZip(t, zipEntry{name: "/etc/evil.json", content: "x"}),
+		"backslash":      buildZip(t, zipEntry{name: `..\evil.json`, content: "x"}),
+		"symlink":        buildZip(t, zipEntry{name: "link", content: "/etc/passwd", mode: os.ModeSymlink | 0o777}),
+		"duplicate":      buildZip(t, zipEntry{name: "en.json", content: "a"}, zipEntry{name: "./en.json", content: "b"}),
+		"safe then evil": buildZip(t, zipEntry{name: "en.json", content: "a"}, zipEntry{name: "../evil.json", content: "x"}),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "out")
+			_, err := extractZip(data, dir, BundleOptions{})
+			if !errors.Is(err, ErrUnsafeBundleEntry) {
+				t.Errorf(assertionTemplate, "error", err, ErrUnsafeBundleEntry)
+			}
+			if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+				t.Errorf("files were written before the bundle was rejected: %v", entries)
+			}
+		})
+	}
+
+	t.Run("existing symlink", func(t *testing.T) {
+		dir, outside := t.TempDir(), t.TempDir()
+		if err := os.Symlink(outside, filepath.Join(dir, "locale")); err != nil {
+			t.Skip("symlinks not supported:", err)
+		}
+		_, err := extractZip(buildZip(t, zipEntry{name: "locale/en.json", content: "x"}), dir, BundleOptions{})
+		if !errors.Is(err, ErrUnsafeBundleEntry) {
+			t.Errorf(assertionTemplate, "error", err, ErrUnsafeBundleEntry)
+		}
+		if _, err := os.Stat(filepath.Join(outside, "en.json")); err == nil {
+			t.Error("file was written through the symlink")
+		}
+	})
+}
+
+func TestExtractBundle_Limits(t *testing.T) {
+	data := buildZip(t, zipEntry{name: "en.json", content: "0123456789"}, zipEntry{name: "de.json", content: "0123456789"})
+
+	for name, opts := range map[string]BundleOptions{
+		"bundle size": {MaxBundleSize: 10},
+		"file size":   {MaxFileSize: 9},
+		"total size":  {MaxTotalSize: 15},
+		"files":       {MaxFiles: 1},
+	} {
+		if _, err := extractZip(data, t.TempDir(), opts); !errors.Is(err, ErrBundleTooLarge) {
+			t.Errorf(assertionTemplate, name, err, ErrBundleTooLarge)
+		}
+	}
+}
+
+func TestExtractBundle_Policies(t *testing.T) {
+	data := buildZip(t, zipEntry{name: "en.json", content: "new"}, zipEntry{name: "de.json", content: "new"})
+	prepare := func() string {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "en.json"), []byte("old"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+	read := func(dir, name string) string {
+		b, _ := os.ReadFile(filepath.Join(dir, name))
+		return string(b)
+	}
+
+	dir := prepare()
+	if _, err := extractZip(data, dir, BundleOptions{Policy: BundleOverwrite}); err != nil || read(dir, "en.json") != "new" {
+		t.Errorf("BundleOverwrite: %v, en.json = %q", err, read(dir, "en.json"))
+	}
+
+	dir = prepare()
+	r, err := extractZip(data, dir, BundleOptions{Policy: BundleKeepExisting})
+	if err != nil || read(dir, "en.json") != "old" || read(dir, "de.json") != "new" {
+		t.Errorf("BundleKeepExisting: %v, en.json = %q", err, read(dir, "en.json"))
+	}
+	if len(r.Kept) != 1 || r.Kept[0].Path != "en.json" || len(r.Written) != 1 {
+		t.Errorf(assertionTemplate, "BundleKeepExisting result", r, "en.json kept")
+	}
+
+	dir = prepare()
+	if _, err := extractZip(data, dir, BundleOptions{Policy: BundleFailOnConflict}); !errors.Is(err, ErrBundleConflict) || read(dir, "de.json") != "" {
+		t.Errorf("BundleFailOnConflict: %v, de.json = %q", err, read(dir, "de.json"))
+	}
+}
+
+func TestFileService_DownloadBundle(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	data := buildZip(t, zipEntry{name: "locale/en.json", content: `{}`})
+	mux.HandleFunc("/bundles/export.zip", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.Header.Get(apiTokenHeader); got != "" {
+			t.Errorf("API token sent to the bundle storage: %q", got)
+		}
+		_, _ = w.Write(data)
+	})
+
+	dir := t.TempDir()
+	r, err := client.Files().DownloadBundle(serverURL+baseURLPath+"/bundles/export.zip", dir, BundleOptions{})
+	if err != nil {
+		t.Fatalf("Files.DownloadBundle returned error: %v", err)
+	}
+	want := []BundleFile{{Path: "locale/en.json", LangISO: "en", Size: 2}}
+	if !reflect.DeepEqual(r.Written, want) {
+		t.Errorf(assertionTemplate, "Files.DownloadBundle", r.Written, want)
+	}
+
+	_, err = client.Files().FetchBundle(serverURL+baseURLPath+"/bundles/export.zip", &bytes.Buffer{}, 10)
+	if !errors.Is(err, ErrBundleTooLarge) {
+		t.Errorf(assertionTemplate, "FetchBundle limit", err, ErrBundleTooLarge)
+	}
+
+	_, err = client.Files().FetchBundle(fmt.Sprintf("%s%s/bundles/missing.zip", serverURL, baseURLPath), &bytes.Buffer{}, 0)
+	if err == nil {
+		t.Error("FetchBundle of a missing bundle returned no error")
+	}
+}
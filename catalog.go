@@ -0,0 +1,244 @@
+package lokalise
+
+import (
+	"reflect"
+	"sort"
+)
+
+// CatalogEntry is the translation of a key in one language.
+type CatalogEntry struct {
+	KeyID   int64
+	KeyName string
+	// Value is the translation of regular keys.
+	Value string
+	// Plural holds the forms of plural keys, Value is empty for them.
+	Plural       PluralTranslation
+	Description  string
+	Context      string
+	Tags         []string
+	ModifiedAtTs int64
+}
+
+// Catalog is an in-memory view of project translations, indexed by language ISO code and key name.
+// Key names are the ones of a single platform. Empty translations are not part of the catalog.
+type Catalog struct {
+	Platform string
+
+	entries map[string]map[string]CatalogEntry
+}
+
+// CatalogChange describes an entry that differs between two catalogs.
+// Fields lists the differing fields for changed entries: value, description, context and tags.
+type CatalogChange struct {
+	LangISO string
+	KeyName string
+	Before  *CatalogEntry
+	After   *CatalogEntry
+	Fields  []string
+}
+
+type CatalogDiff struct {
+	Added   []CatalogChange
+	Removed []CatalogChange
+	Changed []CatalogChange
+}
+
+// IsEmpty reports whether both catalogs were identical.
+func (d CatalogDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// NewCatalog builds a catalog from keys listed with their translations.
+// The key names of the given platform are used, PlatformOther if empty;
+// keys without a name for the platform are left out.
+func NewCatalog(platform string, keys []Key) *Catalog {
+	if platform == "" {
+		platform = PlatformOther
+	}
+	c := &Catalog{Platform: platform, entries: make(map[string]map[string]CatalogEntry)}
+
+	for _, k := range keys {
+		name := k.KeyName.For(platform)
+		if name == "" {
+			continue
+		}
+		for _, t := range k.Translations {
+			if t.Translation == "" {
+				continue
+			}
+			e := CatalogEntry{
+				KeyID:        k.KeyID,
+				KeyName:      name,
+				Description:  k.Description,
+				Context:      k.Context,
+				Tags:         k.Tags,
+				ModifiedAtTs: t.ModifiedAtTs,
+			}
+			if k.IsPlural && t.IsPluralValue() {
+				e.Plural, _ = t.Plural()
+			} else {
+				e.Value = t.Translation
+			}
+			c.Add(t.LanguageISO, e)
+		}
+	}
+	return c
+}
+
+// Catalog fetches the keys of the project with their translations and builds a catalog for the platform.
+// The filter allows to narrow down the catalog, i.e. to some tags or filenames.
+func (c *KeyService) Catalog(projectID, platform string, filter KeyListOptions) (*Catalog, error) {
+	filter.IncludeTranslations = 1
+	keys, err := c.listAll(projectID, filter)
+	if err != nil {
+		return nil, err
+	}
+	return NewCatalog(platform, keys), nil
+}
+
+// Add sets the entry of a language, replacing the existing entry with the same key name.
+func (c *Catalog) Add(langISO string, e CatalogEntry) {
+	if c.entries == nil {
+		c.entries = make(map[string]map[string]CatalogEntry)
+	}
+	if c.entries[langISO] == nil {
+		c.entries[langISO] = make(map[string]CatalogEntry)
+	}
+	c.entries[langISO][e.KeyName] = e
+}
+
+func (c *Catalog) Lookup(langISO, keyName string) (CatalogEntry, bool) {
+	e, ok := c.entries[langISO][keyName]
+	return e, ok
+}
+
+// Get returns the translation value, or the JSON encoded forms of plural keys.
+func (c *Catalog) Get(langISO, keyName string) string {
+	e, ok := c.Lookup(langISO, keyName)
+	if !ok {
+		return ""
+	}
+	if e.Plural != nil {
+		return e.Plural.String()
+	}
+	return e.Value
+}
+
+// Len returns the number of entries in all languages.
+func (c *Catalog) Len() int {
+	n := 0
+	for _, entries := range c.entries {
+		n += len(entries)
+	}
+	return n
+}
+
+// Languages returns the sorted language ISO codes of the catalog.
+func (c *Catalog) Languages() []string {
+	langs := make([]string, 0, len(c.entries))
+	for lang := range c.entries {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// KeyNames returns the sorted key names translated in the language.
+func (c *Catalog) KeyNames(langISO string) []string {
+	names := make([]string, 0, len(c.entries[langISO]))
+	for name := range c.entries[langISO] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Range calls fn for every entry, sorted by language and key name, until fn returns false.
+func (c *Catalog) Range(fn func(langISO string, e CatalogEntry) bool) {
+	for _, lang := range c.Languages() {
+		for _, name := range c.KeyNames(lang) {
+			if !fn(lang, c.entries[lang][name]) {
+				return
+			}
+		}
+	}
+}
+
+// Map returns the translations as language ISO code -> key name -> value, see Get.
+func (c *Catalog) Map() map[string]map[string]string {
+	m := make(map[string]map[string]string, len(c.entries))
+	for lang, entries := range c.entries {
+		m[lang] = make(map[string]string, len(entries))
+		for name := range entries {
+			m[lang][name] = c.Get(lang, name)
+		}
+	}
+	return m
+}
+
+// Diff compares c, i.e. an earlier fetch or the main branch, with other.
+// Added entries only exist in other, removed ones only exist in c.
+func (c *Catalog) Diff(other *Catalog) CatalogDiff {
+	var d CatalogDiff
+
+	c.Range(func(lang string, before CatalogEntry) bool {
+		after, ok := other.Lookup(lang, before.KeyName)
+		if !ok {
+			d.Removed = append(d.Removed, CatalogChange{LangISO: lang, KeyName: before.KeyName, Before: &before})
+			return true
+		}
+		if fields := changedCatalogFields(before, after); len(fields) > 0 {
+			d.Changed = append(d.Changed, CatalogChange{
+				LangISO: lang,
+				KeyName: before.KeyName,
+				Before:  &before,
+				After:   &after,
+				Fields:  fields,
+			})
+		}
+		return true
+	})
+
+	other.Range(func(lang string, after CatalogEntry) bool {
+		if _, ok := c.Lookup(lang, after.KeyName); !ok {
+			d.Added = append(d.Added, CatalogChange{LangISO: lang, KeyName: after.KeyName, After: &after})
+		}
+		return true
+	})
+
+	return d
+}
+
+func changedCatalogFields(before, after CatalogEntry) []string {
+	var fields []string
+	if before.Value != after.Value || !reflect.DeepEqual(before.Plural, after.Plural) {
+		fields = append(fields, "value")
+	}
+	if before.Description != after.Description {
+		fields = append(fields, "description")
+	}
+	if before.Context != after.Context {
+		fields = append(fields, "context")
+	}
+	if !equalStringSets(before.Tags, after.Tags) {
+		fields = append(fields, "tags")
+	}
+	return fields
+}
+
+func equalStringSets(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[string]int, len(a))
+	for _, s := range a {
+		set[s]++
+	}
+	for _, s := range b {
+		if set[s] == 0 {
+			return false
+		}
+		set[s]--
+	}
+	return true
+}
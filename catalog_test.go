@@ -0,0 +1,107 @@
+package lokalise
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestKeyService_Catalog(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/keys", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			if got := r.URL.Query().Get("include_translations"); got != "1" {
+				t.Errorf("include_translations = %q, want 1", got)
+			}
+
+			_, _ = fmt.Fprint(w, `{
+				"keys": [
+					{
+						"key_id": 1,
+						"key_name": {"ios": "Welcome", "android": "welcome", "web": "index.welcome", "other": "welcome"},
+						"description": "Title",
+						"tags": ["index"],
+						"translations": [
+							{"language_iso": "en", "translation": "Welcome", "modified_at_timestamp": 100},
+							{"language_iso": "de", "translation": ""}
+						]
+					},
+					{
+						"key_id": 2,
+						"key_name": {"ios": "", "android": "files", "web": "", "other": ""},
+						"is_plural": true,
+						"translations": [
+							{"language_iso": "en", "translation": "{\"one\":\"1 file\",\"other\":\"{n} files\"}"}
+						]
+					}
+				]
+			}`)
+		})
+
+	c, err := client.Keys().Catalog(testProjectID, PlatformAndroid, KeyListOptions{})
+	if err != nil {
+		t.Errorf("Keys.Catalog returned error: %v", err)
+	}
+
+	want := map[string]map[string]string{
+		"en": {
+			"welcome": "Welcome",
+			"files":   `{"one":"1 file","other":"{n} files"}`,
+		},
+	}
+	if got := c.Map(); !reflect.DeepEqual(got, want) {
+		t.Errorf(assertionTemplate, "Catalog.Map", got, want)
+	}
+
+	e, ok := c.Lookup("en", "files")
+	if !ok || !reflect.DeepEqual(e.Plural, PluralTranslation{"one": "1 file", "other": "{n} files"}) {
+		t.Errorf("Catalog.Lookup returned %+v, %v", e, ok)
+	}
+	if got := c.KeyNames("en"); !reflect.DeepEqual(got, []string{"files", "welcome"}) {
+		t.Errorf("Catalog.KeyNames returned %v", got)
+	}
+}
+
+func TestCatalog_Diff(t *testing.T) {
+	before := &Catalog{}
+	before.Add("en", CatalogEntry{KeyName: "welcome", Value: "Welcome", Tags: []string{"a", "b"}})
+	before.Add("en", CatalogEntry{KeyName: "bye", Value: "Bye"})
+	before.Add("de", CatalogEntry{KeyName: "welcome", Value: "Willkommen"})
+
+	after := &Catalog{}
+	after.Add("en", CatalogEntry{KeyName: "welcome", Value: "Welcome!", Tags: []string{"b", "a"}, Description: "Title"})
+	after.Add("de", CatalogEntry{KeyName: "welcome", Value: "Willkommen"})
+	after.Add("de", CatalogEntry{KeyName: "bye", Value: "Tschüss"})
+
+	d := before.Diff(after)
+
+	want := CatalogDiff{
+		Added: []CatalogChange{
+			{LangISO: "de", KeyName: "bye", After: &CatalogEntry{KeyName: "bye", Value: "Tschüss"}},
+		},
+		Removed: []CatalogChange{
+			{LangISO: "en", KeyName: "bye", Before: &CatalogEntry{KeyName: "bye", Value: "Bye"}},
+		},
+		Changed: []CatalogChange{
+			{
+				LangISO: "en",
+				KeyName: "welcome",
+				Before:  &CatalogEntry{KeyName: "welcome", Value: "Welcome", Tags: []string{"a", "b"}},
+				After:   &CatalogEntry{KeyName: "welcome", Value: "Welcome!", Tags: []string{"b", "a"}, Description: "Title"},
+				Fields:  []string{"value", "description"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf(assertionTemplate, "Catalog.Diff", d, want)
+	}
+	if before.Diff(before).IsEmpty() != true {
+		t.Errorf("Catalog.Diff of the same catalog is not empty")
+	}
+}
@@ -0,0 +1,145 @@
+package lokalise
+
+import (
+	"fmt"
+
+	"github.com/rivo/uniseg"
+)
+
+// CharLimitViolation is a translation exceeding the character limit of its key.
+type CharLimitViolation struct {
+	KeyID         int64
+	KeyName       PlatformStrings
+	TranslationID int64
+	LanguageISO   string
+	// PluralForm is set for the translations of plural keys, every form is checked separately.
+	PluralForm string
+	Limit      int
+	Length     int
+}
+
+// CharLimitError is returned by ValidateCharLimits.
+type CharLimitError struct {
+	Violations []CharLimitViolation
+}
+
+func (e CharLimitError) Error() string {
+	v := e.Violations[0]
+	msg := fmt.Sprintf("lokalise: %s translation has %d characters, limit is %d", v.LanguageISO, v.Length, v.Limit)
+	if len(e.Violations) > 1 {
+		msg += fmt.Sprintf(" (and %d more violations)", len(e.Violations)-1)
+	}
+	return msg
+}
+
+// CountChars returns the number of user perceived characters of s, i.e. its grapheme clusters.
+// Combined emojis and letters with combining marks count as one character.
+func CountChars(s string) int {
+	return uniseg.GraphemeClusterCount(s)
+}
+
+// CheckCharLimit checks a translation value against a character limit, a zero limit means no limit.
+// The forms of plural values are checked separately. Violations are only filled with the
+// plural form, the limit and the length.
+func CheckCharLimit(limit int, value string, isPlural bool) []CharLimitViolation {
+	if limit <= 0 || value == "" {
+		return nil
+	}
+	if isPlural && isPluralValue(value) {
+		p, _ := ParsePluralTranslation(value)
+		var violations []CharLimitViolation
+		for _, form := range p.Forms() {
+			if n := CountChars(p[form]); n > limit {
+				violations = append(violations, CharLimitViolation{PluralForm: form, Limit: limit, Length: n})
+			}
+		}
+		return violations
+	}
+	if n := CountChars(value); n > limit {
+		return []CharLimitViolation{{Limit: limit, Length: n}}
+	}
+	return nil
+}
+
+// CharLimitViolations checks the translations of the key against its character limit.
+func (k Key) CharLimitViolations() []CharLimitViolation {
+	var violations []CharLimitViolation
+	for _, t := range k.Translations {
+		for _, v := range CheckCharLimit(k.CharLimit, t.Translation, k.IsPlural) {
+			v.KeyID = k.KeyID
+			v.KeyName = k.KeyName
+			v.TranslationID = t.TranslationID
+			v.LanguageISO = t.LanguageISO
+			violations = append(violations, v)
+		}
+	}
+	return violations
+}
+
+// CharLimitViolations checks the translations of a key about to be created against its character limit.
+func (k NewKey) CharLimitViolations() []CharLimitViolation {
+	if k.CharLimit == nil || k.Translations == nil {
+		return nil
+	}
+	var name PlatformStrings
+	switch n := k.KeyName.(type) {
+	case string:
+		name = PlatformStrings{Ios: n, Android: n, Web: n, Other: n}
+	case *string:
+		name = PlatformStrings{Ios: *n, Android: *n, Web: *n, Other: *n}
+	case PlatformStrings:
+		name = n
+	case *PlatformStrings:
+		name = *n
+	}
+	isPlural := k.IsPlural != nil && *k.IsPlural
+
+	var violations []CharLimitViolation
+	for _, t := range *k.Translations {
+		for _, v := range CheckCharLimit(*k.CharLimit, t.Translation, isPlural) {
+			v.KeyName = name
+			v.LanguageISO = t.LanguageISO
+			violations = append(violations, v)
+		}
+	}
+	return violations
+}
+
+// CharLimitViolations checks a translation update against the character limit of its key.
+func (t UpdateTranslation) CharLimitViolations(key Key, languageISO string) []CharLimitViolation {
+	violations := CheckCharLimit(key.CharLimit, t.Translation, key.IsPlural)
+	for i := range violations {
+		violations[i].KeyID = key.KeyID
+		violations[i].KeyName = key.KeyName
+		violations[i].LanguageISO = languageISO
+	}
+	return violations
+}
+
+// ValidateCharLimits returns a CharLimitError if any of the keys to be created exceeds its character limit.
+func ValidateCharLimits(keys []NewKey) error {
+	var violations []CharLimitViolation
+	for _, k := range keys {
+		violations = append(violations, k.CharLimitViolations()...)
+	}
+	if len(violations) > 0 {
+		return CharLimitError{Violations: violations}
+	}
+	return nil
+}
+
+// AuditCharLimits reports every translation of the project exceeding the character limit of its key.
+// The filter allows to narrow down the audit, i.e. to some languages or tags.
+func (c *KeyService) AuditCharLimits(projectID string, filter KeyListOptions) ([]CharLimitViolation, error) {
+	filter.IncludeTranslations = 1
+	keys, err := c.listAll(projectID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []CharLimitViolation
+	for _, k := range keys {
+		violations = append(violations, k.CharLimitViolations()...)
+	}
+	return violations, nil
+}
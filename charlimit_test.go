@@ -0,0 +1,104 @@
+package lokalise
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCountChars(t *testing.T) {
+	for s, want := range map[string]int{
+		"":                     0,
+		"Save":                 4,
+		"Grāmata":              7,
+		"e\u0301":              1, // combining acute accent
+		"\U0001F44D\U0001F3FD": 1, // emoji with skin tone modifier
+		"\U0001F468\u200D\U0001F469\u200D\U0001F467": 1, // ZWJ sequence
+	} {
+		if got := CountChars(s); got != want {
+			t.Errorf("CountChars(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func TestNewKey_CharLimitViolations(t *testing.T) {
+	limit := 5
+	translations := []NewTranslation{
+		{LanguageISO: "en", Translation: `{"one":"1 file","other":"%d files"}`},
+		{LanguageISO: "de", Translation: `{"one":"1 Datei","other":"%d Dateien"}`},
+	}
+	key := NewKey{
+		KeyName:      "files",
+		IsPlural:     Bool(true),
+		CharLimit:    &limit,
+		Translations: &translations,
+	}
+
+	name := PlatformStrings{Ios: "files", Android: "files", Web: "files", Other: "files"}
+	want := []CharLimitViolation{
+		{KeyName: name, LanguageISO: "en", PluralForm: "one", Limit: 5, Length: 6},
+		{KeyName: name, LanguageISO: "en", PluralForm: "other", Limit: 5, Length: 8},
+		{KeyName: name, LanguageISO: "de", PluralForm: "one", Limit: 5, Length: 7},
+		{KeyName: name, LanguageISO: "de", PluralForm: "other", Limit: 5, Length: 10},
+	}
+	if got := key.CharLimitViolations(); !reflect.DeepEqual(got, want) {
+		t.Errorf(assertionTemplate, "NewKey.CharLimitViolations", got, want)
+	}
+
+	err := ValidateCharLimits([]NewKey{key})
+	if got := err.Error(); got != "lokalise: en translation has 6 characters, limit is 5 (and 3 more violations)" {
+		t.Errorf("ValidateCharLimits returned %q", got)
+	}
+}
+
+func TestKeyService_AuditCharLimits(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/keys", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			if got := r.URL.Query().Get("include_translations"); got != "1" {
+				t.Errorf("include_translations = %q, want 1", got)
+			}
+
+			_, _ = fmt.Fprint(w, `{
+				"keys": [
+					{
+						"key_id": 1,
+						"key_name": {"ios": "save", "android": "save", "web": "save", "other": "save"},
+						"char_limit": 6,
+						"translations": [
+							{"translation_id": 10, "language_iso": "en", "translation": "Save"},
+							{"translation_id": 11, "language_iso": "de", "translation": "Speichern"},
+							{"translation_id": 12, "language_iso": "ru", "translation": "Сохрани"}
+						]
+					},
+					{
+						"key_id": 2,
+						"key_name": {"ios": "cancel", "android": "cancel", "web": "cancel", "other": "cancel"},
+						"translations": [
+							{"translation_id": 20, "language_iso": "de", "translation": "Abbrechen"}
+						]
+					}
+				]
+			}`)
+		})
+
+	r, err := client.Keys().AuditCharLimits(testProjectID, KeyListOptions{})
+	if err != nil {
+		t.Errorf("Keys.AuditCharLimits returned error: %v", err)
+	}
+
+	name := PlatformStrings{Ios: "save", Android: "save", Web: "save", Other: "save"}
+	want := []CharLimitViolation{
+		{KeyID: 1, KeyName: name, TranslationID: 11, LanguageISO: "de", Limit: 6, Length: 9},
+		{KeyID: 1, KeyName: name, TranslationID: 12, LanguageISO: "ru", Limit: 6, Length: 7},
+	}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf(assertionTemplate, "Keys.AuditCharLimits", r, want)
+	}
+}
@@ -21,6 +21,11 @@ type restClient struct {
 	baseURL    string
 	apiToken   string
 	retryCount int
+
+	// retryTooManyRequests retries the requests failing with a 429 status code, see WithRetryOnRateLimit.
+	retryTooManyRequests bool
+	// limiter throttles the requests when set with WithRateLimit, including the streamed ones.
+	limiter *rateLimiter
 }
 
 func newClient(apiToken string) *restClient {
@@ -35,26 +40,27 @@ func newClient(apiToken string) *restClient {
 		SetRetryCount(c.retryCount).
 		SetHeader(apiTokenHeader, c.apiToken).
 		SetError(errorResponse{}).
-		AddRetryCondition(requestRetryCondition())
+		AddRetryCondition(c.requestRetryCondition())
 
 	return &c
 }
 
 // requestRetryCondition indicates a retry if the HTTP status code of the response
-// is >= 500.
+// is >= 500, or 429 (too many requests) if enabled with WithRetryOnRateLimit.
 // failing requests due to network conditions, eg. "no such host", are handled by resty internally
-func requestRetryCondition() resty.RetryConditionFunc {
+func (c *restClient) requestRetryCondition() resty.RetryConditionFunc {
 	return func(res *resty.Response, err error) bool {
 		if res == nil || err != nil {
 			return true
 		}
-		if res.StatusCode() >= http.StatusInternalServerError {
-			return true
-		}
-		return false
+		return c.retryStatus(res.StatusCode())
 	}
 }
 
+func (c *restClient) retryStatus(status int) bool {
+	return status >= http.StatusInternalServerError || (c.retryTooManyRequests && status == http.StatusTooManyRequests)
+}
+
 func (c *restClient) get(ctx context.Context, path string, res interface{}) (*resty.Response, error) {
 	return c.req(ctx, path, res).Get(path)
 }
@@ -0,0 +1,350 @@
+/*
+Package config loads project settings, i.e. a lokalise.yml kept next to the locale files,
+into the FileUpload, FileDownload and PushOptions values of the lokalise package.
+
+The file is YAML or JSON. Upload and download settings use the field names of the API.
+Strings may reference environment variables as $VAR, ${VAR} or ${VAR:-default}; $$ is a literal $.
+Unknown fields and unset variables without default are errors.
+
+	projects:
+	  web:
+	    id: ${LOKALISE_PROJECT_ID}
+	    branch: ${LOKALISE_BRANCH:-}
+	    push:
+	      dir: locales
+	      patterns: ["%LANG_ISO%/*.json"]
+	      state_file: .lokalise-state.json
+	      upload:
+	        tags: [ci]
+	        replace_modified: true
+	    download_defaults:
+	      format: json
+	      original_filenames: false
+	    downloads:
+	      web:
+	        bundle_structure: "locales/%LANG_ISO%.json"
+	        placeholder_format: i18n
+
+Usage:
+
+	cfg, err := config.LoadFile("lokalise.yml")
+	web, err := cfg.Project("web")
+	download, err := web.Download("web")
+	resp, err := client.Files().Download(web.ProjectID(), download)
+*/
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lokalise/go-lokalise-api/v5"
+)
+
+type Config struct {
+	Projects map[string]Project
+}
+
+type Project struct {
+	// Name is the key of the project in the config.
+	Name   string
+	ID     string
+	Branch string
+	Push   *Push
+	// Downloads are the download presets by name, each applied over the project download defaults
+	// and validated with FileDownload.Validate.
+	Downloads map[string]lokalise.FileDownload
+}
+
+type Push struct {
+	// Dir is the directory holding the locale files. Relative to the config file when loaded with LoadFile.
+	Dir      string
+	Patterns []string
+	Workers  int
+	// StateFile is the upload state skipping unchanged files, see lokalise.FileUploadState.
+	StateFile string
+	Upload    lokalise.FileUpload
+}
+
+type rawConfig struct {
+	Projects map[string]rawProject `json:"projects"`
+}
+
+type rawProject struct {
+	ID               string                     `json:"id"`
+	Branch           string                     `json:"branch"`
+	Push             *rawPush                   `json:"push"`
+	DownloadDefaults json.RawMessage            `json:"download_defaults"`
+	Downloads        map[string]json.RawMessage `json:"downloads"`
+}
+
+type rawPush struct {
+	Dir       string          `json:"dir"`
+	Patterns  []string        `json:"patterns"`
+	Workers   int             `json:"workers"`
+	StateFile string          `json:"state_file"`
+	Upload    json.RawMessage `json:"upload"`
+}
+
+// LoadFile reads and parses a config file, see Parse.
+// Relative push directories and state files are resolved against the directory of the file.
+func LoadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := parse(data, os.LookupEnv)
+	if err != nil {
+		return nil, fmt.Errorf("config: %s: %w", path, err)
+	}
+
+	base := filepath.Dir(path)
+	for name, p := range cfg.Projects {
+		if p.Push == nil {
+			continue
+		}
+		if !filepath.IsAbs(p.Push.Dir) {
+			p.Push.Dir = filepath.Join(base, p.Push.Dir)
+		}
+		if p.Push.StateFile != "" && !filepath.IsAbs(p.Push.StateFile) {
+			p.Push.StateFile = filepath.Join(base, p.Push.StateFile)
+		}
+		cfg.Projects[name] = p
+	}
+	return cfg, nil
+}
+
+// Parse parses a YAML or JSON config, expanding variables from the environment.
+func Parse(data []byte) (*Config, error) {
+	return ParseWithEnv(data, os.LookupEnv)
+}
+
+// ParseWithEnv parses a YAML or JSON config, expanding variables with lookupEnv.
+func ParseWithEnv(data []byte, lookupEnv func(string) (string, bool)) (*Config, error) {
+	cfg, err := parse(data, lookupEnv)
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	return cfg, nil
+}
+
+func parse(data []byte, lookupEnv func(string) (string, bool)) (*Config, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	doc, err := normalize(doc, "", lookupEnv)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw rawConfig
+	if err := decodeStrict(b, &raw); err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{Projects: make(map[string]Project, len(raw.Projects))}
+	for name, rp := range raw.Projects {
+		p, err := rp.build(name)
+		if err != nil {
+			return nil, fmt.Errorf("project %s: %w", name, err)
+		}
+		cfg.Projects[name] = p
+	}
+	return cfg, nil
+}
+
+// Project returns a project by name.
+func (c *Config) Project(name string) (Project, error) {
+	p, ok := c.Projects[name]
+	if !ok {
+		return Project{}, fmt.Errorf("config: unknown project %q", name)
+	}
+	return p, nil
+}
+
+// ProjectID returns the project ID to use with the API, including the branch if any.
+func (p Project) ProjectID() string {
+	if p.Branch == "" {
+		return p.ID
+	}
+	return p.ID + ":" + p.Branch
+}
+
+// Download returns a download preset by name.
+func (p Project) Download(preset string) (lokalise.FileDownload, error) {
+	d, ok := p.Downloads[preset]
+	if !ok {
+		return lokalise.FileDownload{}, fmt.Errorf("config: project %s has no download preset %q", p.Name, preset)
+	}
+	return d, nil
+}
+
+// PushOptions returns the options of FileService.PushDir, opening the upload state if a state file is set.
+func (p Project) PushOptions() (lokalise.PushOptions, error) {
+	if p.Push == nil {
+		return lokalise.PushOptions{}, fmt.Errorf("config: project %s has no push settings", p.Name)
+	}
+	opts := lokalise.PushOptions{
+		Patterns: p.Push.Patterns,
+		Defaults: p.Push.Upload,
+		Workers:  p.Push.Workers,
+	}
+	if p.Push.StateFile != "" {
+		state, err := lokalise.OpenFileUploadState(p.Push.StateFile)
+		if err != nil {
+			return opts, err
+		}
+		opts.State = state
+	}
+	return opts, nil
+}
+
+func (rp rawProject) build(name string) (p Project, err error) {
+	if rp.ID == "" {
+		return p, fmt.Errorf("id is required")
+	}
+	if strings.Contains(rp.ID, ":") {
+		return p, fmt.Errorf("id %q must not contain a branch, use branch", rp.ID)
+	}
+	p = Project{Name: name, ID: rp.ID, Branch: rp.Branch}
+
+	if rp.Push != nil {
+		if p.Push, err = rp.Push.build(); err != nil {
+			return p, fmt.Errorf("push: %w", err)
+		}
+	}
+
+	p.Downloads = make(map[string]lokalise.FileDownload, len(rp.Downloads))
+	for preset, raw := range rp.Downloads {
+		var d lokalise.FileDownload
+		if len(rp.DownloadDefaults) > 0 {
+			if err := decodeStrict(rp.DownloadDefaults, &d); err != nil {
+				return p, fmt.Errorf("download_defaults: %w", err)
+			}
+		}
+		if err := decodeStrict(raw, &d); err != nil {
+			return p, fmt.Errorf("download %s: %w", preset, err)
+		}
+		if err := d.Validate(); err != nil {
+			return p, fmt.Errorf("download %s: %w", preset, err)
+		}
+		p.Downloads[preset] = d
+	}
+	return p, nil
+}
+
+func (rp rawPush) build() (*Push, error) {
+	if len(rp.Patterns) == 0 {
+		return nil, fmt.Errorf("patterns are required")
+	}
+	if rp.Workers < 0 {
+		return nil, fmt.Errorf("workers must not be negative")
+	}
+	push := &Push{Dir: rp.Dir, Patterns: rp.Patterns, Workers: rp.Workers, StateFile: rp.StateFile}
+	if push.Dir == "" {
+		push.Dir = "."
+	}
+	if len(rp.Upload) > 0 {
+		if err := decodeStrict(rp.Upload, &push.Upload); err != nil {
+			return nil, fmt.Errorf("upload: %w", err)
+		}
+	}
+	if push.Upload.Data != "" {
+		return nil, fmt.Errorf("upload: data is set per file")
+	}
+	return push, nil
+}
+
+// decodeStrict decodes JSON, rejecting unknown fields.
+func decodeStrict(data []byte, v any) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// normalize converts a decoded YAML document to JSON compatible values, expanding variables in strings.
+func normalize(v any, path string, lookupEnv func(string) (string, bool)) (any, error) {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			n, err := normalize(e, path+"/"+k, lookupEnv)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = n
+		}
+		return out, nil
+	case map[any]any:
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: key %v is not a string", path, k)
+			}
+			n, err := normalize(e, path+"/"+key, lookupEnv)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = n
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			n, err := normalize(e, fmt.Sprintf("%s/%d", path, i), lookupEnv)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = n
+		}
+		return out, nil
+	case string:
+		s, err := expand(v, lookupEnv)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return s, nil
+	default:
+		return v, nil
+	}
+}
+
+// expand replaces $VAR, ${VAR} and ${VAR:-default} in s, $$ being a literal $.
+func expand(s string, lookupEnv func(string) (string, bool)) (string, error) {
+	var missing []string
+	out := os.Expand(s, func(name string) string {
+		if name == "$" {
+			return "$"
+		}
+		name, def, hasDefault := strings.Cut(name, ":-")
+		if value, ok := lookupEnv(name); ok && (value != "" || !hasDefault) {
+			return value
+		}
+		if hasDefault {
+			return def
+		}
+		missing = append(missing, name)
+		return ""
+	})
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return "", fmt.Errorf("environment variable %s is not set", strings.Join(missing, ", "))
+	}
+	return out, nil
+}
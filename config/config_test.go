@@ -0,0 +1,165 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lokalise/go-lokalise-api/v5"
+)
+
+const testConfig = `
+projects:
+  web:
+    id: ${PROJECT_ID}
+    branch: ${BRANCH:-main}
+    push:
+      dir: locales
+      patterns: ["%LANG_ISO%/*.json"]
+      workers: 2
+      state_file: .state.json
+      upload:
+        tags: [ci, "build-$BUILD"]
+        replace_modified: true
+        cleanup_mode: true
+    download_defaults:
+      format: json
+      original_filenames: false
+      placeholder_format: icu
+    downloads:
+      web:
+        bundle_structure: "locales/%LANG_ISO%.json"
+        placeholder_format: i18n
+      ios:
+        format: ios_sdk
+        language_mapping:
+          - {original_language_iso: pt_BR, custom_language_iso: pt-BR}
+`
+
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+}
+
+func TestParseWithEnv(t *testing.T) {
+	cfg, err := ParseWithEnv([]byte(testConfig), env(map[string]string{"PROJECT_ID": "3002780358964f9bab5a92.87762498", "BUILD": "42"}))
+	if err != nil {
+		t.Fatalf("ParseWithEnv returned error: %v", err)
+	}
+	web, err := cfg.Project("web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := web.ProjectID(); got != "3002780358964f9bab5a92.87762498:main" {
+		t.Errorf("ProjectID = %q", got)
+	}
+
+	wantPush := &Push{
+		Dir:       "locales",
+		Patterns:  []string{"%LANG_ISO%/*.json"},
+		Workers:   2,
+		StateFile: ".state.json",
+		Upload:    lokalise.FileUpload{Tags: []string{"ci", "build-42"}, ReplaceModified: true, CleanupMode: true},
+	}
+	if !reflect.DeepEqual(web.Push, wantPush) {
+		t.Errorf("Push = %+v, want %+v", web.Push, wantPush)
+	}
+
+	d, err := web.Download("web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := lokalise.FileDownload{
+		Format:            "json",
+		OriginalFilenames: lokalise.Bool(false),
+		BundleStructure:   "locales/%LANG_ISO%.json",
+		PlaceholderFormat: "i18n",
+	}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("Download(web) = %+v, want %+v", d, want)
+	}
+
+	d, _ = web.Download("ios")
+	if d.Format != "ios_sdk" || d.PlaceholderFormat != "icu" || len(d.LanguageMapping) != 1 || d.LanguageMapping[0].CustomLangISO != "pt-BR" {
+		t.Errorf("Download(ios) = %+v", d)
+	}
+	if _, err := web.Download("android"); err == nil {
+		t.Error("Download of an unknown preset returned no error")
+	}
+	if _, err := cfg.Project("api"); err == nil {
+		t.Error("Project of an unknown project returned no error")
+	}
+}
+
+func TestParseWithEnv_Errors(t *testing.T) {
+	tests := map[string]string{
+		"unset variable":   "projects: {web: {id: $PROJECT_ID}}",
+		"unknown field":    "projects: {web: {id: p1, downloads: {web: {format: json, bundle_structur: x}}}}",
+		"unknown section":  "projects: {web: {id: p1, pull: {}}}",
+		"missing id":       "projects: {web: {branch: main}}",
+		"branch in id":     "projects: {web: {id: \"p1:main\"}}",
+		"missing format":   "projects: {web: {id: p1, downloads: {web: {}}}}",
+		"missing patterns": "projects: {web: {id: p1, push: {dir: locales}}}",
+		"upload data":      "projects: {web: {id: p1, push: {patterns: [x], upload: {data: abc}}}}",
+		"wrong type":       "projects: {web: {id: p1, push: {patterns: x}}}",
+	}
+	for name, data := range tests {
+		if _, err := ParseWithEnv([]byte(data), env(nil)); err == nil || !strings.HasPrefix(err.Error(), "config: ") {
+			t.Errorf("%s: error = %v", name, err)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	lookup := env(map[string]string{"A": "a", "EMPTY": ""})
+	for in, want := range map[string]string{
+		"$A-${A}":         "a-a",
+		"${EMPTY:-def}":   "def",
+		"${UNSET:-def}":   "def",
+		"${EMPTY}":        "",
+		"cost: $$5":       "cost: $5",
+		"%LANG_ISO%.json": "%LANG_ISO%.json",
+	} {
+		if got, err := expand(in, lookup); err != nil || got != want {
+			t.Errorf("expand(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "lokalise.json")
+	data := `{"projects": {"web": {"id": "p1", "push": {"dir": "locales", "patterns": ["%LANG_ISO%.json"], "state_file": "state.json"}}}}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile returned error: %v", err)
+	}
+	web, _ := cfg.Project("web")
+	if web.Push.Dir != filepath.Join(dir, "locales") {
+		t.Errorf("Push.Dir = %q, want relative to the config file", web.Push.Dir)
+	}
+
+	opts, err := web.PushOptions()
+	if err != nil {
+		t.Fatalf("PushOptions returned error: %v", err)
+	}
+	if opts.State == nil || !reflect.DeepEqual(opts.Patterns, []string{"%LANG_ISO%.json"}) {
+		t.Errorf("PushOptions = %+v", opts)
+	}
+}
+
+func TestParseWithEnv_InvalidDownload(t *testing.T) {
+	data := "projects: {web: {id: p1, downloads: {web: {format: json, yaml_include_root: true}}}}"
+	_, err := ParseWithEnv([]byte(data), env(nil))
+	if err == nil || !strings.Contains(err.Error(), "download web: lokalise: invalid file download: yaml_include_root") {
+		t.Errorf("error = %v", err)
+	}
+}
@@ -27,6 +27,10 @@ You can set global API parameters with the ClientOption functions during the ini
 
 * WithPageLimit
 
+* WithRateLimit
+
+* WithRetryOnRateLimit
+
 Usage:
 
 	Api, err := lokalise.New(
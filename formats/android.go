@@ -0,0 +1,244 @@
+package formats
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/lokalise/go-lokalise-api/v5"
+)
+
+// AndroidXML is the strings.xml format of Android resources, with plurals.
+// The comment preceding a string is its description, contexts are not stored.
+// Inline markup is read as text and written escaped. String arrays are not supported.
+type AndroidXML struct{}
+
+func (c AndroidXML) Format() lokalise.FileFormat { return lokalise.FormatXML }
+func (c AndroidXML) Platform() string            { return lokalise.PlatformAndroid }
+
+type androidString struct {
+	Name  string `xml:"name,attr"`
+	Inner string `xml:",innerxml"`
+}
+
+type androidPlurals struct {
+	Name  string `xml:"name,attr"`
+	Items []struct {
+		Quantity string `xml:"quantity,attr"`
+		Inner    string `xml:",innerxml"`
+	} `xml:"item"`
+}
+
+func (c AndroidXML) Decode(r io.Reader, langISO string) ([]lokalise.Key, error) {
+	dec := xml.NewDecoder(r)
+	var (
+		entries []entry
+		comment string
+	)
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("formats: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.Comment:
+			comment = strings.TrimSpace(string(t))
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "resources":
+				continue
+			case "string":
+				var s androidString
+				if err := dec.DecodeElement(&s, &t); err != nil {
+					return nil, fmt.Errorf("formats: %w", err)
+				}
+				value, err := androidText(s.Inner)
+				if err != nil {
+					return nil, fmt.Errorf("formats: string %q: %w", s.Name, err)
+				}
+				e := singularEntry(s.Name, langISO, value)
+				e.description = comment
+				entries = append(entries, e)
+			case "plurals":
+				var p androidPlurals
+				if err := dec.DecodeElement(&p, &t); err != nil {
+					return nil, fmt.Errorf("formats: %w", err)
+				}
+				forms := make(lokalise.PluralTranslation, len(p.Items))
+				for _, item := range p.Items {
+					value, err := androidText(item.Inner)
+					if err != nil {
+						return nil, fmt.Errorf("formats: plurals %q: %w", p.Name, err)
+					}
+					forms[item.Quantity] = value
+				}
+				e := pluralEntry(p.Name, langISO, forms)
+				e.description = comment
+				entries = append(entries, e)
+			case "string-array":
+				for _, a := range t.Attr {
+					if a.Name.Local == "name" {
+						return nil, fmt.Errorf("formats: string-array %q is not supported", a.Value)
+					}
+				}
+				return nil, errors.New("formats: string-array is not supported")
+			default:
+				if err := dec.Skip(); err != nil {
+					return nil, fmt.Errorf("formats: %w", err)
+				}
+			}
+			comment = ""
+		}
+	}
+	return keysFromEntries(c.Platform(), entries), nil
+}
+
+func (c AndroidXML) Encode(w io.Writer, langISO string, keys []lokalise.Key) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<resources>\n")
+	for _, e := range entriesFromKeys(c.Platform(), keys, langISO) {
+		if e.description != "" {
+			bw.WriteString("    <!-- " + strings.ReplaceAll(e.description, "--", "- -") + " -->\n")
+		}
+		if forms := e.forms(langISO); forms != nil {
+			bw.WriteString("    <plurals name=\"" + xmlEscape(e.name) + "\">\n")
+			for _, f := range forms.Forms() {
+				bw.WriteString("        <item quantity=\"" + xmlEscape(f) + "\">" + xmlEscape(androidEscape(forms[f])) + "</item>\n")
+			}
+			bw.WriteString("    </plurals>\n")
+			continue
+		}
+		bw.WriteString("    <string name=\"" + xmlEscape(e.name) + "\">" + xmlEscape(androidEscape(e.values[langISO])) + "</string>\n")
+	}
+	bw.WriteString("</resources>\n")
+	return bw.Flush()
+}
+
+// androidText returns the text of an element content, inline markup kept as written, with Android escapes resolved.
+func androidText(inner string) (string, error) {
+	text, err := xmlInnerText(inner)
+	if err != nil {
+		return "", err
+	}
+	return androidUnescape(text), nil
+}
+
+func androidUnescape(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' && !strings.HasSuffix(s, `\"`) {
+		s = s[1 : len(s)-1]
+	}
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			if i+4 < len(s) {
+				if r, err := strconv.ParseUint(s[i+1:i+5], 16, 32); err == nil {
+					b.WriteRune(rune(r))
+					i += 4
+					continue
+				}
+			}
+			b.WriteString(`\u`)
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+func androidEscape(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\'':
+			b.WriteString(`\'`)
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case i == 0 && (r == '@' || r == '?'):
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// xmlInnerText resolves the entities and CDATA sections of an element content, keeping nested elements as markup.
+func xmlInnerText(inner string) (string, error) {
+	if !strings.ContainsAny(inner, "<&") {
+		return inner, nil
+	}
+	dec := xml.NewDecoder(strings.NewReader("<x>" + inner + "</x>"))
+	var b strings.Builder
+	depth := 0
+	for {
+		tok, err := dec.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			b.Write(t)
+		case xml.StartElement:
+			depth++
+			if depth == 1 {
+				continue
+			}
+			b.WriteString("<" + xmlName(t.Name))
+			for _, a := range t.Attr {
+				b.WriteString(" " + xmlName(a.Name) + "=\"" + xmlEscape(a.Value) + "\"")
+			}
+			b.WriteString(">")
+		case xml.EndElement:
+			depth--
+			if depth > 0 {
+				b.WriteString("</" + xmlName(t.Name) + ">")
+			}
+		}
+	}
+	return b.String(), nil
+}
+
+func xmlName(n xml.Name) string {
+	if n.Space != "" {
+		return n.Space + ":" + n.Local
+	}
+	return n.Local
+}
+
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,167 @@
+package formats
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"github.com/lokalise/go-lokalise-api/v5"
+)
+
+// DiffReport lists the keys a pull would change in the working tree, per language.
+// It is meant to be marshaled as JSON, i.e. for pull request comments.
+type DiffReport struct {
+	Languages []LanguageDiff `json:"languages"`
+}
+
+type LanguageDiff struct {
+	LangISO string `json:"lang_iso"`
+	// Added are the keys of the bundle missing locally.
+	Added []KeyDiff `json:"added,omitempty"`
+	// Removed are the local keys missing from the bundle.
+	Removed []KeyDiff `json:"removed,omitempty"`
+	// Changed are the keys translated differently in the bundle.
+	Changed []KeyDiff `json:"changed,omitempty"`
+}
+
+// KeyDiff is a key of a file, plural values being JSON objects of plural forms.
+type KeyDiff struct {
+	File   string `json:"file"`
+	Key    string `json:"key"`
+	Local  string `json:"local,omitempty"`
+	Remote string `json:"remote,omitempty"`
+}
+
+// HasChanges reports whether any key would change.
+func (r DiffReport) HasChanges() bool {
+	for _, l := range r.Languages {
+		if len(l.Added)+len(l.Removed)+len(l.Changed) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+type DiffOptions struct {
+	// Export tells how the bundle is generated, ExtractDir is managed by the diff.
+	Export lokalise.ExportOptions
+	// Codec decodes the files, it defaults to the codec of the download format,
+	// or of the file extension for formats without codec.
+	Codec Codec
+}
+
+// Diff exports the bundle of the download preset to a temporary directory and compares every file
+// to the file of the same path in dir at the key level. Local files absent from the bundle are not compared.
+func Diff(files *lokalise.FileService, projectID string, download lokalise.FileDownload, dir string, opts DiffOptions) (DiffReport, error) {
+	tmp, err := os.MkdirTemp("", "lokalise-diff-")
+	if err != nil {
+		return DiffReport{}, err
+	}
+	defer os.RemoveAll(tmp)
+
+	opts.Export.ExtractDir = tmp
+	r, err := files.Export(projectID, download, opts.Export)
+	if err != nil {
+		return DiffReport{}, err
+	}
+	codec := opts.Codec
+	if codec == nil {
+		codec, _ = ForFormat(lokalise.FileFormat(download.Format))
+	}
+	return DiffBundle(dir, tmp, r.Bundle, codec)
+}
+
+// DiffBundle compares the files of a bundle extracted into bundleDir to the files of the same path in dir.
+// The codec of the file extension is used if codec is nil.
+func DiffBundle(dir, bundleDir string, bundle lokalise.BundleResult, codec Codec) (DiffReport, error) {
+	byLang := make(map[string]*LanguageDiff)
+	for _, f := range bundle.Written {
+		c := codec
+		if c == nil {
+			var err error
+			if c, err = ForFile(f.Path); err != nil {
+				return DiffReport{}, err
+			}
+		}
+
+		remote, err := readDiffFile(c, filepath.Join(bundleDir, filepath.FromSlash(f.Path)), f.LangISO)
+		if err != nil {
+			return DiffReport{}, err
+		}
+		local, err := readDiffFile(c, filepath.Join(dir, filepath.FromSlash(f.Path)), f.LangISO)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return DiffReport{}, err
+		}
+
+		l, ok := byLang[f.LangISO]
+		if !ok {
+			l = &LanguageDiff{LangISO: f.LangISO}
+			byLang[f.LangISO] = l
+		}
+		diffKeys(l, f.Path, local, remote)
+	}
+
+	var r DiffReport
+	for _, l := range byLang {
+		r.Languages = append(r.Languages, *l)
+	}
+	sort.Slice(r.Languages, func(i, j int) bool { return r.Languages[i].LangISO < r.Languages[j].LangISO })
+	return r, nil
+}
+
+// diffValue is the translation of a key in a file, with its parsed plural forms.
+type diffValue struct {
+	value string
+	forms lokalise.PluralTranslation
+}
+
+func readDiffFile(c Codec, path, langISO string) (map[string]diffValue, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	keys, err := c.Decode(f, langISO)
+	if err != nil {
+		return nil, fmt.Errorf("formats: %s: %w", path, err)
+	}
+	values := make(map[string]diffValue, len(keys))
+	for _, e := range entriesFromKeys(c.Platform(), keys, langISO) {
+		values[e.name] = diffValue{value: e.values[langISO], forms: e.forms(langISO)}
+	}
+	return values, nil
+}
+
+func diffKeys(l *LanguageDiff, file string, local, remote map[string]diffValue) {
+	names := make([]string, 0, len(local)+len(remote))
+	for name := range remote {
+		names = append(names, name)
+	}
+	for name := range local {
+		if _, ok := remote[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		lv, inLocal := local[name]
+		rv, inRemote := remote[name]
+		d := KeyDiff{File: file, Key: name, Local: lv.value, Remote: rv.value}
+		switch {
+		case !inLocal:
+			l.Added = append(l.Added, d)
+		case !inRemote:
+			l.Removed = append(l.Removed, d)
+		case lv.value == rv.value:
+		case lv.forms != nil && rv.forms != nil && reflect.DeepEqual(lv.forms, rv.forms):
+		default:
+			l.Changed = append(l.Changed, d)
+		}
+	}
+}
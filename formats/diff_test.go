@@ -0,0 +1,93 @@
+package formats
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lokalise/go-lokalise-api/v5"
+)
+
+func TestDiff(t *testing.T) {
+	var bundle bytes.Buffer
+	zw := zip.NewWriter(&bundle)
+	for name, content := range map[string]string{
+		"locale/de.json": `{"home": {"title": "Startseite", "items": {"one": "1 Artikel", "other": "{n} Artikel"}}, "new": "Neu", "same": "Gleich"}`,
+		"locale/en.json": `{"home": {"title": "Home"}}`,
+	} {
+		w, _ := zw.Create(name)
+		_, _ = w.Write([]byte(content))
+	}
+	_ = zw.Close()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/api2/projects/123.abc/files/download", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"project_id": "123.abc", "bundle_url": "%s/bundle.zip"}`, server.URL)
+	})
+	mux.HandleFunc("/bundle.zip", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(bundle.Bytes())
+	})
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "locale"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	local := `{"home": {"title": "Start", "items": {"other": "{n} Artikel", "one": "1 Artikel"}}, "old": "Alt", "same": "Gleich"}`
+	if err := os.WriteFile(filepath.Join(dir, "locale", "de.json"), []byte(local), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := lokalise.New("token", lokalise.WithBaseURL(server.URL+"/api2/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := Diff(client.Files(), "123.abc", lokalise.FileDownload{Format: string(lokalise.FormatJSON)}, dir, DiffOptions{
+		Export: lokalise.ExportOptions{Mode: lokalise.ExportSync},
+	})
+	if err != nil {
+		t.Fatalf("Diff returned error: %v", err)
+	}
+	if !r.HasChanges() {
+		t.Error("HasChanges = false")
+	}
+
+	got, _ := json.Marshal(r)
+	want := `{"languages":[` +
+		`{"lang_iso":"de",` +
+		`"added":[{"file":"locale/de.json","key":"new","remote":"Neu"}],` +
+		`"removed":[{"file":"locale/de.json","key":"old","local":"Alt"}],` +
+		`"changed":[{"file":"locale/de.json","key":"home.title","local":"Start","remote":"Startseite"}]},` +
+		`{"lang_iso":"en",` +
+		`"added":[{"file":"locale/en.json","key":"home.title","remote":"Home"}]}]}`
+	if string(got) != want {
+		t.Errorf("Diff JSON\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestDiffBundle_Unchanged(t *testing.T) {
+	dir, bundleDir := t.TempDir(), t.TempDir()
+	for _, d := range []string{dir, bundleDir} {
+		if err := os.WriteFile(filepath.Join(d, "de.properties"), []byte("a=b\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bundle := lokalise.BundleResult{Written: []lokalise.BundleFile{{Path: "de.properties", LangISO: "de"}}}
+
+	r, err := DiffBundle(dir, bundleDir, bundle, nil)
+	if err != nil {
+		t.Fatalf("DiffBundle returned error: %v", err)
+	}
+	if r.HasChanges() || len(r.Languages) != 1 {
+		t.Errorf("DiffBundle = %+v, want no changes for de", r)
+	}
+}
@@ -0,0 +1,221 @@
+/*
+Package formats reads and writes common localization file formats locally, i.e. to preview or diff
+files without a server round trip.
+
+Each Codec converts a file of one language to and from the Key and Translation model of the lokalise package.
+Key names are set for the platform of the codec, plural keys hold their translations as JSON objects of plural forms,
+like the API does. Descriptions and contexts are kept where the format has a place for them.
+
+Usage:
+
+	codec, err := formats.ForFile("values-de/strings.xml")
+	keys, err := codec.Decode(f, "de")
+
+	err = formats.JSON{Nested: true}.Encode(w, "de", keys)
+
+	keys, err = formats.ReadFile("locales/de.json", "de")
+	report, err := client.Keys().Import(projectID, lokalise.FileUpload{Filename: "de.json", LangISO: "de"}, keys)
+
+	diff, err := formats.Diff(client.Files(), projectID, preset, ".", formats.DiffOptions{})
+	out, err := json.MarshalIndent(diff, "", "  ")
+*/
+package formats
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/lokalise/go-lokalise-api/v5"
+)
+
+// Codec reads and writes the files of a localization format.
+type Codec interface {
+	Format() lokalise.FileFormat
+	// Platform is the platform of the key names, see lokalise.PlatformStrings.
+	Platform() string
+	// Decode reads the keys of a file holding the translations of langISO.
+	// Bilingual formats also return the translations of their source language.
+	Decode(r io.Reader, langISO string) ([]lokalise.Key, error)
+	// Encode writes the translations of langISO, keys without translation in that language are left out.
+	Encode(w io.Writer, langISO string, keys []lokalise.Key) error
+}
+
+// ForFormat returns the codec of a file format with its default options.
+func ForFormat(format lokalise.FileFormat) (Codec, error) {
+	switch format {
+	case lokalise.FormatJSON:
+		return JSON{Nested: true}, nil
+	case lokalise.FormatJSONStructured:
+		return StructuredJSON{}, nil
+	case lokalise.FormatARB:
+		return ARB{}, nil
+	case lokalise.FormatXML:
+		return AndroidXML{}, nil
+	case lokalise.FormatStrings:
+		return Strings{}, nil
+	case lokalise.FormatStringsDict:
+		return StringsDict{}, nil
+	case lokalise.FormatXLIFF, lokalise.FormatXLF:
+		return XLIFF{}, nil
+	case lokalise.FormatPO:
+		return PO{}, nil
+	case lokalise.FormatYAML, lokalise.FormatYML:
+		return YAML{}, nil
+	case lokalise.FormatProperties:
+		return Properties{}, nil
+	}
+	return nil, fmt.Errorf("formats: unsupported format %q", format)
+}
+
+// ForFile returns the codec of a file, based on its extension.
+func ForFile(path string) (Codec, error) {
+	format := lokalise.FileFormat(strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), "."))
+	switch format {
+	case "pot":
+		format = lokalise.FormatPO
+	case "":
+		return nil, fmt.Errorf("formats: no format for %s", path)
+	}
+	return ForFormat(format)
+}
+
+// ReadFile decodes the keys of a local file holding the translations of langISO, with the codec for its
+// extension. The keys can be imported into a project with KeyService.Import.
+func ReadFile(path, langISO string) ([]lokalise.Key, error) {
+	codec, err := ForFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	keys, err := codec.Decode(f, langISO)
+	if err != nil {
+		return nil, fmt.Errorf("formats: %s: %w", path, err)
+	}
+	return keys, nil
+}
+
+// entry is a key of a file with its translations, plural values being JSON objects of plural forms.
+type entry struct {
+	name        string
+	description string
+	context     string
+	plural      bool
+	pluralName  string
+	values      map[string]string
+}
+
+func singularEntry(name, langISO, value string) entry {
+	return entry{name: name, values: map[string]string{langISO: value}}
+}
+
+func pluralEntry(name, langISO string, forms lokalise.PluralTranslation) entry {
+	return entry{name: name, plural: true, values: map[string]string{langISO: forms.String()}}
+}
+
+// forms returns the plural forms of a plural entry, or nil.
+func (e entry) forms(langISO string) lokalise.PluralTranslation {
+	v, ok := e.values[langISO]
+	if !ok || !e.plural {
+		return nil
+	}
+	p, err := lokalise.ParsePluralTranslation(v)
+	if err != nil {
+		return nil
+	}
+	return p
+}
+
+// keysFromEntries converts decoded entries to keys named for the platform.
+func keysFromEntries(platform string, entries []entry) []lokalise.Key {
+	keys := make([]lokalise.Key, 0, len(entries))
+	for _, e := range entries {
+		k := lokalise.Key{
+			KeyName:     platformStrings(platform, e.name),
+			Description: e.description,
+			Context:     e.context,
+			Platforms:   []string{platform},
+			IsPlural:    e.plural,
+			PluralName:  e.pluralName,
+		}
+		langs := make([]string, 0, len(e.values))
+		for lang := range e.values {
+			langs = append(langs, lang)
+		}
+		sort.Strings(langs)
+		for _, lang := range langs {
+			k.Translations = append(k.Translations, lokalise.Translation{LanguageISO: lang, Translation: e.values[lang]})
+		}
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// entriesFromKeys converts the keys translated in langISO to entries with the translations of langs.
+func entriesFromKeys(platform string, keys []lokalise.Key, langISO string, langs ...string) []entry {
+	entries := make([]entry, 0, len(keys))
+	for _, k := range keys {
+		name := keyName(k, platform)
+		if name == "" {
+			continue
+		}
+		e := entry{
+			name:        name,
+			description: k.Description,
+			context:     k.Context,
+			plural:      k.IsPlural,
+			pluralName:  k.PluralName,
+			values:      make(map[string]string),
+		}
+		for _, t := range k.Translations {
+			if t.LanguageISO == langISO || slices.Contains(langs, t.LanguageISO) {
+				e.values[t.LanguageISO] = t.Translation
+			}
+		}
+		if _, ok := e.values[langISO]; ok {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// keyName returns the name of a key for the platform, or its first name set.
+func keyName(k lokalise.Key, platform string) string {
+	if name := k.KeyName.For(platform); name != "" {
+		return name
+	}
+	for _, name := range []string{k.KeyName.Other, k.KeyName.Web, k.KeyName.Ios, k.KeyName.Android} {
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+func platformStrings(platform, name string) lokalise.PlatformStrings {
+	switch platform {
+	case lokalise.PlatformIos:
+		return lokalise.PlatformStrings{Ios: name}
+	case lokalise.PlatformAndroid:
+		return lokalise.PlatformStrings{Android: name}
+	case lokalise.PlatformWeb:
+		return lokalise.PlatformStrings{Web: name}
+	}
+	return lokalise.PlatformStrings{Other: name}
+}
+
+// pluralForms lists the CLDR plural forms in order.
+var pluralForms = []string{"zero", "one", "two", "few", "many", "other"}
+
+func isPluralForm(s string) bool {
+	return slices.Contains(pluralForms, s)
+}
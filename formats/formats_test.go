@@ -0,0 +1,336 @@
+package formats
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lokalise/go-lokalise-api/v5"
+)
+
+// testKey is the comparable part of a decoded key.
+type testKey struct {
+	Value       string
+	Description string
+	Context     string
+	Plural      bool
+}
+
+func testKeys() []lokalise.Key {
+	key := func(name, description, context string, plural bool, en, de string) lokalise.Key {
+		return lokalise.Key{
+			KeyName:     lokalise.PlatformStrings{Ios: name, Android: name, Web: name, Other: name},
+			Description: description,
+			Context:     context,
+			IsPlural:    plural,
+			Translations: []lokalise.Translation{
+				{LanguageISO: "en", Translation: en},
+				{LanguageISO: "de", Translation: de},
+			},
+		}
+	}
+	return []lokalise.Key{
+		key("greeting", "Shown on start", "home", false, "Hello, \"world\"!\nBye", "Hallo, \"Welt\"!\nTschüss"),
+		key("menu.title", "", "", false, "Tom's <b>menu</b> & more", "Toms <b>Menü</b> & mehr"),
+		key("items", "Item count", "", true, `{"one":"%d item","other":"%d items"}`, `{"one":"%d Artikel","other":"%d Artikel"}`),
+		key("emoji", "", "", false, "@home ✓ 😀", "@zuhause ✓ 😀"),
+		key("untranslated", "", "", false, "Only English", ""),
+	}
+}
+
+func indexKeys(t *testing.T, keys []lokalise.Key, platform, langISO string) map[string]testKey {
+	t.Helper()
+	index := make(map[string]testKey)
+	for _, k := range keys {
+		name := k.KeyName.For(platform)
+		if name == "" {
+			t.Errorf("key %+v has no %s name", k.KeyName, platform)
+		}
+		for _, tr := range k.Translations {
+			if tr.LanguageISO != langISO {
+				continue
+			}
+			value := tr.Translation
+			if k.IsPlural {
+				p, err := lokalise.ParsePluralTranslation(value)
+				if err != nil {
+					t.Fatalf("key %s: %v", name, err)
+				}
+				value = p.String()
+			}
+			index[name] = testKey{Value: value, Description: k.Description, Context: k.Context, Plural: k.IsPlural}
+		}
+	}
+	return index
+}
+
+func TestCodecs_RoundTrip(t *testing.T) {
+	tests := []struct {
+		codec                Codec
+		description, context bool
+		singular, plural     bool
+	}{
+		{JSON{}, false, false, true, true},
+		{JSON{Nested: true}, false, false, true, true},
+		{StructuredJSON{}, true, true, true, true},
+		{StructuredJSON{Nested: true}, true, true, true, true},
+		{ARB{}, true, true, true, true},
+		{AndroidXML{}, true, false, true, true},
+		{Strings{}, true, false, true, false},
+		{StringsDict{}, false, false, false, true},
+		{XLIFF{SourceLang: "en"}, true, true, true, true},
+		{XLIFF{Version: "2.0", SourceLang: "en"}, true, true, true, true},
+		{PO{}, true, true, true, true},
+		{YAML{}, true, false, true, true},
+		{YAML{IncludeRoot: true}, true, false, true, true},
+		{Properties{}, true, false, true, true},
+		{Properties{UTF8: true}, true, false, true, true},
+	}
+
+	keys := testKeys()
+	keys[4].Translations = keys[4].Translations[:1] // no German translation
+	all := indexKeys(t, keys, lokalise.PlatformOther, "de")
+
+	for _, tt := range tests {
+		t.Run(reflect.TypeOf(tt.codec).Name(), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.codec.Encode(&buf, "de", keys); err != nil {
+				t.Fatalf("Encode returned error: %v", err)
+			}
+			decoded, err := tt.codec.Decode(bytes.NewReader(buf.Bytes()), "de")
+			if err != nil {
+				t.Fatalf("Decode returned error: %v\n%s", err, buf.String())
+			}
+
+			want := make(map[string]testKey)
+			for name, k := range all {
+				if k.Plural && !tt.plural || !k.Plural && !tt.singular {
+					continue
+				}
+				if !tt.description {
+					k.Description = ""
+				}
+				if !tt.context {
+					k.Context = ""
+				}
+				want[name] = k
+			}
+			if got := indexKeys(t, decoded, tt.codec.Platform(), "de"); !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip\n got: %+v\nwant: %+v\nfile:\n%s", got, want, buf.String())
+			}
+		})
+	}
+}
+
+func TestXLIFF_Decode_Source(t *testing.T) {
+	for _, version := range []string{"1.2", "2.0"} {
+		var buf bytes.Buffer
+		if err := (XLIFF{Version: version, SourceLang: "en"}).Encode(&buf, "de", testKeys()); err != nil {
+			t.Fatal(err)
+		}
+		keys, err := XLIFF{}.Decode(&buf, "de")
+		if err != nil {
+			t.Fatalf("Decode %s returned error: %v", version, err)
+		}
+		en := indexKeys(t, keys, lokalise.PlatformWeb, "en")
+		if en["greeting"].Value != "Hello, \"world\"!\nBye" || en["items"].Value != `{"one":"%d item","other":"%d items"}` {
+			t.Errorf("XLIFF %s sources = %+v", version, en)
+		}
+	}
+}
+
+func TestCodecs_Decode(t *testing.T) {
+	tests := []struct {
+		codec Codec
+		file  string
+		want  map[string]testKey
+	}{
+		{
+			JSON{},
+			`{"nav": {"home": "Home", "items": {"one": "1 item", "other": "{{count}} items"}}, "flat.key": "Flat"}`,
+			map[string]testKey{
+				"nav.home":  {Value: "Home"},
+				"nav.items": {Value: `{"one":"1 item","other":"{{count}} items"}`, Plural: true},
+				"flat.key":  {Value: "Flat"},
+			},
+		},
+		{
+			StructuredJSON{},
+			`{"welcome": {"translation": "Hello", "notes": "Greeting", "context": "home", "limit": 20},
+			  "cart": {"items": {"translation": {"one": "1 item", "other": "{{count}} items"}}}}`,
+			map[string]testKey{
+				"welcome":    {Value: "Hello", Description: "Greeting", Context: "home"},
+				"cart.items": {Value: `{"one":"1 item","other":"{{count}} items"}`, Plural: true},
+			},
+		},
+		{
+			ARB{},
+			`{"@@locale": "de", "count": "{n, plural, one{Ein {thing}} other{{n} Dinge}}", "@count": {"description": "Things", "placeholders": {}}}`,
+			map[string]testKey{
+				"count": {Value: `{"one":"Ein {thing}","other":"{n} Dinge"}`, Description: "Things", Plural: true},
+			},
+		},
+		{
+			AndroidXML{},
+			`<?xml version="1.0" encoding="utf-8"?>
+<resources>
+    <!-- Title -->
+    <string name="title">Tom\'s \"App\"</string>
+    <string name="html">Hi <b>you</b> &amp; <![CDATA[<i>me</i>]]></string>
+    <plurals name="songs"><item quantity="one">%d song</item><item quantity="other">%d songs</item></plurals>
+</resources>`,
+			map[string]testKey{
+				"title": {Value: `Tom's "App"`, Description: "Title"},
+				"html":  {Value: "Hi <b>you</b> & <i>me</i>"},
+				"songs": {Value: `{"one":"%d song","other":"%d songs"}`, Plural: true},
+			},
+		},
+		{
+			Strings{},
+			"/* Greeting */\n\"hello\" = \"Hallo\\n\\\"du\\\"\";\n// Unquoted\nbye = \"Tsch\\U00fcss\";\n",
+			map[string]testKey{
+				"hello": {Value: "Hallo\n\"du\"", Description: "Greeting"},
+				"bye":   {Value: "Tschüss", Description: "Unquoted"},
+			},
+		},
+		{
+			PO{},
+			`# translator comment
+msgid ""
+msgstr ""
+"Language: ru\n"
+
+#. Shown in the menu
+#: src/menu.c:12
+msgctxt "menu"
+msgid "open"
+msgstr ""
+"Открыть\n"
+"файл"
+
+msgid "file"
+msgid_plural "files"
+msgstr[0] "файл"
+msgstr[1] "файла"
+msgstr[2] "файлов"
+
+#~ msgid "old"
+#~ msgstr "старый"
+`,
+			map[string]testKey{
+				"open": {Value: "Открыть\nфайл", Description: "Shown in the menu", Context: "menu"},
+				"file": {Value: `{"few":"файла","one":"файл","other":"файлов"}`, Plural: true},
+			},
+		},
+		{
+			YAML{IncludeRoot: true},
+			"de:\n  # Page title\n  title: Titel\n  yes: \"no\"\n  cart:\n    items:\n      one: 1 Artikel\n      other: '%{count} Artikel'\n",
+			map[string]testKey{
+				"title":      {Value: "Titel", Description: "Page title"},
+				"yes":        {Value: "no"},
+				"cart.items": {Value: `{"one":"1 Artikel","other":"%{count} Artikel"}`, Plural: true},
+			},
+		},
+		{
+			Properties{},
+			"# Greeting\ngreeting = Hallo \\\n    Welt\nname:Gr\\u00fc\\u00dfe\n! other comment\n\nkey\\ with\\ spaces value\nlatin=caf\xe9\n",
+			map[string]testKey{
+				"greeting":        {Value: "Hallo Welt", Description: "Greeting"},
+				"name":            {Value: "Grüße"},
+				"key with spaces": {Value: "value"},
+				"latin":           {Value: "café"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(reflect.TypeOf(tt.codec).Name(), func(t *testing.T) {
+			keys, err := tt.codec.Decode(strings.NewReader(tt.file), "de")
+			if err != nil {
+				t.Fatalf("Decode returned error: %v", err)
+			}
+			if got := indexKeys(t, keys, tt.codec.Platform(), "de"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decode\n got: %+v\nwant: %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrings_Decode_UTF16(t *testing.T) {
+	text := "\"a\" = \"ä\";"
+	data := []byte{0xFF, 0xFE}
+	for _, r := range text {
+		data = append(data, byte(r), byte(r>>8))
+	}
+	keys, err := Strings{}.Decode(bytes.NewReader(data), "de")
+	if err != nil || len(keys) != 1 || keys[0].Translations[0].Translation != "ä" {
+		t.Errorf("Decode UTF-16 = %+v, %v", keys, err)
+	}
+}
+
+func TestAndroidXML_Decode_StringArray(t *testing.T) {
+	data := `<resources><string name="a">A</string><string-array name="planets"><item>Mars</item></string-array></resources>`
+	_, err := AndroidXML{}.Decode(strings.NewReader(data), "en")
+	if err == nil || !strings.Contains(err.Error(), `"planets"`) {
+		t.Errorf("Decode of a string-array = %v, want an error", err)
+	}
+}
+
+func TestJSON_Encode_Conflict(t *testing.T) {
+	keys := []lokalise.Key{
+		{KeyName: lokalise.PlatformStrings{Web: "a"}, Translations: []lokalise.Translation{{LanguageISO: "en", Translation: "A"}}},
+		{KeyName: lokalise.PlatformStrings{Web: "a.b"}, Translations: []lokalise.Translation{{LanguageISO: "en", Translation: "B"}}},
+	}
+	if err := (JSON{Nested: true}).Encode(&bytes.Buffer{}, "en", keys); err == nil {
+		t.Error("Encode of conflicting nested names returned no error")
+	}
+	if err := (YAML{}).Encode(&bytes.Buffer{}, "en", keys); err == nil {
+		t.Error("YAML Encode of conflicting nested names returned no error")
+	}
+}
+
+func TestForFile(t *testing.T) {
+	for path, want := range map[string]Codec{
+		"locales/de.json":              JSON{Nested: true},
+		"values-de/strings.xml":        AndroidXML{},
+		"de.lproj/Main.strings":        Strings{},
+		"de.lproj/Plurals.stringsdict": StringsDict{},
+		"messages.xlf":                 XLIFF{},
+		"messages.pot":                 PO{},
+		"config/locales/de.yml":        YAML{},
+		"Messages_de.properties":       Properties{},
+		"intl_de.arb":                  ARB{},
+	} {
+		got, err := ForFile(path)
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("ForFile(%s) = %#v, %v", path, got, err)
+		}
+	}
+	if _, err := ForFile("README.md"); err == nil {
+		t.Error("ForFile of an unsupported file returned no error")
+	}
+	if got, err := ForFormat(lokalise.FormatJSONStructured); err != nil || got != (StructuredJSON{}) {
+		t.Errorf("ForFormat(json_structured) = %#v, %v", got, err)
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "de.json")
+	if err := os.WriteFile(path, []byte(`{"home": {"title": "Startseite"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := ReadFile(path, "de")
+	if err != nil {
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+	want := map[string]testKey{"home.title": {Value: "Startseite"}}
+	if got := indexKeys(t, keys, lokalise.PlatformWeb, "de"); !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadFile = %+v, want %+v", got, want)
+	}
+	if len(keys) != 1 || !reflect.DeepEqual(keys[0].Platforms, []string{lokalise.PlatformWeb}) {
+		t.Errorf("ReadFile platforms = %+v", keys)
+	}
+}
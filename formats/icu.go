@@ -0,0 +1,80 @@
+package formats
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/lokalise/go-lokalise-api/v5"
+)
+
+// defaultPluralName is the argument of written ICU plurals when the key has no plural name.
+const defaultPluralName = "count"
+
+// parseICUPlural parses a message made of a single ICU plural argument, i.e. "{count, plural, one{# item} other{# items}}".
+// Only CLDR plural keywords are supported, messages with offsets or explicit values are not plurals.
+func parseICUPlural(s string) (name string, forms lokalise.PluralTranslation, ok bool) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '{' || closingBrace(s, 0) != len(s)-1 {
+		return "", nil, false
+	}
+	parts := strings.SplitN(s[1:len(s)-1], ",", 3)
+	if len(parts) != 3 || strings.TrimSpace(parts[1]) != "plural" {
+		return "", nil, false
+	}
+	name = strings.TrimSpace(parts[0])
+
+	forms = make(lokalise.PluralTranslation)
+	rest := parts[2]
+	for {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if rest == "" {
+			break
+		}
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			return "", nil, false
+		}
+		form := strings.TrimSpace(rest[:open])
+		end := closingBrace(rest, open)
+		if end < 0 || !isPluralForm(form) {
+			return "", nil, false
+		}
+		forms[form] = rest[open+1 : end]
+		rest = rest[end+1:]
+	}
+	if len(forms) == 0 {
+		return "", nil, false
+	}
+	return name, forms, true
+}
+
+// formatICUPlural writes plural forms as an ICU plural message.
+func formatICUPlural(name string, forms lokalise.PluralTranslation) string {
+	if name == "" {
+		name = defaultPluralName
+	}
+	var b strings.Builder
+	b.WriteString("{" + name + ", plural,")
+	for _, f := range forms.Forms() {
+		b.WriteString(" " + f + "{" + forms[f] + "}")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// closingBrace returns the index of the brace closing the one at open, or -1.
+func closingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,365 @@
+package formats
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/lokalise/go-lokalise-api/v5"
+)
+
+// Strings is the .strings format of Apple platforms. The comment preceding a string is its description,
+// contexts are not stored. Plural keys are left out, see StringsDict.
+// Decoding accepts UTF-8 and UTF-16 files with a byte order mark, files are written in UTF-8.
+type Strings struct{}
+
+func (c Strings) Format() lokalise.FileFormat { return lokalise.FormatStrings }
+func (c Strings) Platform() string            { return lokalise.PlatformIos }
+
+func (c Strings) Decode(r io.Reader, langISO string) ([]lokalise.Key, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := stringsParser{s: decodeBOM(data)}
+
+	var (
+		entries []entry
+		comment string
+	)
+	for {
+		p.skipSpace()
+		switch {
+		case p.i >= len(p.s):
+			return keysFromEntries(c.Platform(), entries), nil
+		case strings.HasPrefix(p.s[p.i:], "/*"):
+			end := strings.Index(p.s[p.i+2:], "*/")
+			if end < 0 {
+				return nil, p.errorf("unterminated comment")
+			}
+			comment = strings.TrimSpace(p.s[p.i+2 : p.i+2+end])
+			p.i += end + 4
+		case strings.HasPrefix(p.s[p.i:], "//"):
+			end := strings.IndexByte(p.s[p.i:], '\n')
+			if end < 0 {
+				end = len(p.s) - p.i
+			}
+			comment = strings.TrimSpace(p.s[p.i+2 : p.i+end])
+			p.i += end
+		default:
+			name, err := p.token()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect('='); err != nil {
+				return nil, err
+			}
+			value, err := p.token()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(';'); err != nil {
+				return nil, err
+			}
+			e := singularEntry(name, langISO, value)
+			e.description = comment
+			entries = append(entries, e)
+			comment = ""
+		}
+	}
+}
+
+func (c Strings) Encode(w io.Writer, langISO string, keys []lokalise.Key) error {
+	bw := bufio.NewWriter(w)
+	for _, e := range entriesFromKeys(c.Platform(), keys, langISO) {
+		if e.plural {
+			continue
+		}
+		if e.description != "" {
+			bw.WriteString("/* " + strings.ReplaceAll(e.description, "*/", "* /") + " */\n")
+		}
+		bw.WriteString(quoteStrings(e.name) + " = " + quoteStrings(e.values[langISO]) + ";\n\n")
+	}
+	return bw.Flush()
+}
+
+type stringsParser struct {
+	s string
+	i int
+}
+
+func (p *stringsParser) errorf(format string, args ...any) error {
+	line := strings.Count(p.s[:min(p.i, len(p.s))], "\n") + 1
+	return fmt.Errorf("formats: strings line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+func (p *stringsParser) skipSpace() {
+	for p.i < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.i]) >= 0 {
+		p.i++
+	}
+}
+
+func (p *stringsParser) expect(c byte) error {
+	p.skipSpace()
+	if p.i >= len(p.s) || p.s[p.i] != c {
+		return p.errorf("expected %q", c)
+	}
+	p.i++
+	return nil
+}
+
+// token reads a quoted string or an unquoted word.
+func (p *stringsParser) token() (string, error) {
+	p.skipSpace()
+	if p.i >= len(p.s) {
+		return "", p.errorf("unexpected end of file")
+	}
+	if p.s[p.i] != '"' {
+		start := p.i
+		for p.i < len(p.s) && (isWordByte(p.s[p.i])) {
+			p.i++
+		}
+		if start == p.i {
+			return "", p.errorf("unexpected %q", p.s[p.i])
+		}
+		return p.s[start:p.i], nil
+	}
+
+	var b strings.Builder
+	for p.i++; p.i < len(p.s); p.i++ {
+		switch ch := p.s[p.i]; ch {
+		case '"':
+			p.i++
+			return b.String(), nil
+		case '\\':
+			p.i++
+			if p.i >= len(p.s) {
+				return "", p.errorf("unterminated string")
+			}
+			switch esc := p.s[p.i]; esc {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case '0':
+				b.WriteByte(0)
+			case 'u', 'U':
+				if p.i+4 >= len(p.s) {
+					return "", p.errorf("invalid unicode escape")
+				}
+				r, err := strconv.ParseUint(p.s[p.i+1:p.i+5], 16, 32)
+				if err != nil {
+					return "", p.errorf("invalid unicode escape")
+				}
+				b.WriteRune(rune(r))
+				p.i += 4
+			default:
+				b.WriteByte(esc)
+			}
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c == '.' || c == '-' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func quoteStrings(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// decodeBOM returns the text of a file, decoding UTF-16 if it starts with a byte order mark.
+func decodeBOM(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		return string(data[3:])
+	case len(data) >= 2 && (data[0] == 0xFF && data[1] == 0xFE || data[0] == 0xFE && data[1] == 0xFF):
+		little := data[0] == 0xFF
+		units := make([]uint16, 0, len(data)/2)
+		for i := 2; i+1 < len(data); i += 2 {
+			if little {
+				units = append(units, uint16(data[i])|uint16(data[i+1])<<8)
+			} else {
+				units = append(units, uint16(data[i])<<8|uint16(data[i+1]))
+			}
+		}
+		return string(utf16.Decode(units))
+	}
+	return string(data)
+}
+
+// StringsDict is the .stringsdict plural format of Apple platforms. Only plural keys with a single
+// plural variable are supported, the variable being the plural name of the key. Values are written as
+// integers (%d) and the text around the variable in the format key is not stored.
+type StringsDict struct{}
+
+func (c StringsDict) Format() lokalise.FileFormat { return lokalise.FormatStringsDict }
+func (c StringsDict) Platform() string            { return lokalise.PlatformIos }
+
+var stringsDictVariableRegexp = regexp.MustCompile(`%#@([^@]+)@`)
+
+func (c StringsDict) Decode(r io.Reader, langISO string) ([]lokalise.Key, error) {
+	root, err := readPlist(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []entry
+	for _, m := range root {
+		rule, ok := m.value.([]jsonMember)
+		if !ok {
+			continue
+		}
+		format, _ := plistString(rule, "NSStringLocalizedFormatKey")
+		match := stringsDictVariableRegexp.FindStringSubmatch(format)
+		if match == nil {
+			return nil, fmt.Errorf("formats: stringsdict %q has no plural variable", m.name)
+		}
+		variable, ok := plistDict(rule, match[1])
+		if !ok {
+			return nil, fmt.Errorf("formats: stringsdict %q has no rule for %s", m.name, match[1])
+		}
+
+		forms := make(lokalise.PluralTranslation)
+		for _, v := range variable {
+			if s, ok := v.value.(string); ok && isPluralForm(v.name) {
+				forms[v.name] = s
+			}
+		}
+		e := pluralEntry(m.name, langISO, forms)
+		e.pluralName = match[1]
+		entries = append(entries, e)
+	}
+	return keysFromEntries(c.Platform(), entries), nil
+}
+
+func (c StringsDict) Encode(w io.Writer, langISO string, keys []lokalise.Key) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+`)
+	for _, e := range entriesFromKeys(c.Platform(), keys, langISO) {
+		forms := e.forms(langISO)
+		if forms == nil {
+			continue
+		}
+		variable := e.pluralName
+		if variable == "" {
+			variable = defaultPluralName
+		}
+		bw.WriteString("\t<key>" + xmlEscape(e.name) + "</key>\n\t<dict>\n")
+		bw.WriteString("\t\t<key>NSStringLocalizedFormatKey</key>\n\t\t<string>%#@" + xmlEscape(variable) + "@</string>\n")
+		bw.WriteString("\t\t<key>" + xmlEscape(variable) + "</key>\n\t\t<dict>\n")
+		bw.WriteString("\t\t\t<key>NSStringFormatSpecTypeKey</key>\n\t\t\t<string>NSStringPluralRuleType</string>\n")
+		bw.WriteString("\t\t\t<key>NSStringFormatValueTypeKey</key>\n\t\t\t<string>d</string>\n")
+		for _, f := range forms.Forms() {
+			bw.WriteString("\t\t\t<key>" + f + "</key>\n\t\t\t<string>" + xmlEscape(forms[f]) + "</string>\n")
+		}
+		bw.WriteString("\t\t</dict>\n\t</dict>\n")
+	}
+	bw.WriteString("</dict>\n</plist>\n")
+	return bw.Flush()
+}
+
+// readPlist reads the root dictionary of a property list, dictionaries being []jsonMember and strings string.
+func readPlist(r io.Reader) ([]jsonMember, error) {
+	dec := xml.NewDecoder(r)
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("formats: plist has no root dictionary: %w", err)
+		}
+		if start, ok := tok.(xml.StartElement); ok && start.Name.Local == "dict" {
+			v, err := readPlistValue(dec, start)
+			if err != nil {
+				return nil, fmt.Errorf("formats: %w", err)
+			}
+			return v.([]jsonMember), nil
+		}
+	}
+}
+
+func readPlistValue(dec *xml.Decoder, start xml.StartElement) (any, error) {
+	switch start.Name.Local {
+	case "dict":
+		var members []jsonMember
+		var key string
+		for {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			switch t := tok.(type) {
+			case xml.StartElement:
+				if t.Name.Local == "key" {
+					if err := dec.DecodeElement(&key, &t); err != nil {
+						return nil, err
+					}
+					continue
+				}
+				v, err := readPlistValue(dec, t)
+				if err != nil {
+					return nil, err
+				}
+				members = append(members, jsonMember{key, v})
+			case xml.EndElement:
+				return members, nil
+			}
+		}
+	case "array":
+		var items []any
+		for {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			switch t := tok.(type) {
+			case xml.StartElement:
+				v, err := readPlistValue(dec, t)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, v)
+			case xml.EndElement:
+				return items, nil
+			}
+		}
+	case "true", "false":
+		return start.Name.Local == "true", dec.Skip()
+	}
+	var s string
+	err := dec.DecodeElement(&s, &start)
+	return s, err
+}
+
+func plistString(dict []jsonMember, key string) (string, bool) {
+	for _, m := range dict {
+		if s, ok := m.value.(string); ok && m.name == key {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+func plistDict(dict []jsonMember, key string) ([]jsonMember, bool) {
+	for _, m := range dict {
+		if d, ok := m.value.([]jsonMember); ok && m.name == key {
+			return d, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,444 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/lokalise/go-lokalise-api/v5"
+)
+
+// JSON is the key-value JSON format, flat or nested. Plural values are objects of plural forms.
+// Decoding accepts both layouts, nested names being joined with the separator.
+// Descriptions and contexts are not stored.
+type JSON struct {
+	// Nested writes names split at the separator as nested objects.
+	Nested bool
+	// Separator of nested names. Default: "."
+	Separator string
+	// Indent of the written objects. Default: two spaces
+	Indent string
+}
+
+func (c JSON) Format() lokalise.FileFormat { return lokalise.FormatJSON }
+func (c JSON) Platform() string            { return lokalise.PlatformWeb }
+
+func (c JSON) separator() string {
+	if c.Separator == "" {
+		return "."
+	}
+	return c.Separator
+}
+
+func (c JSON) Decode(r io.Reader, langISO string) ([]lokalise.Key, error) {
+	members, err := readJSON(r)
+	if err != nil {
+		return nil, err
+	}
+	var entries []entry
+	if err := c.flatten(&entries, "", members, langISO); err != nil {
+		return nil, err
+	}
+	return keysFromEntries(c.Platform(), entries), nil
+}
+
+func (c JSON) flatten(entries *[]entry, prefix string, members []jsonMember, langISO string) error {
+	for _, m := range members {
+		name := prefix + m.name
+		switch v := m.value.(type) {
+		case string:
+			*entries = append(*entries, singularEntry(name, langISO, v))
+		case []jsonMember:
+			if forms, ok := jsonPluralForms(v); ok {
+				*entries = append(*entries, pluralEntry(name, langISO, forms))
+				continue
+			}
+			if err := c.flatten(entries, name+c.separator(), v, langISO); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("formats: json value of %q is not a string", name)
+		}
+	}
+	return nil
+}
+
+func (c JSON) Encode(w io.Writer, langISO string, keys []lokalise.Key) error {
+	var root []jsonMember
+	for _, e := range entriesFromKeys(c.Platform(), keys, langISO) {
+		value := jsonValue(e, langISO)
+		if !c.Nested {
+			root = append(root, jsonMember{e.name, value})
+			continue
+		}
+		var err error
+		if root, err = insertJSONMember(root, strings.Split(e.name, c.separator()), value); err != nil {
+			return fmt.Errorf("formats: key %q: %w", e.name, err)
+		}
+	}
+	return writeJSON(w, root, c.Indent)
+}
+
+// StructuredJSON is the structured JSON format, each key being an object holding its translation, with the
+// description in notes and the context in context. Plural translations are objects of plural forms.
+// Decoding treats objects without a translation member as groups, their names being joined with the separator.
+// Other members of the keys, like the character limit, are ignored.
+type StructuredJSON struct {
+	// Nested writes names split at the separator as nested groups.
+	Nested bool
+	// Separator of nested names. Default: "."
+	Separator string
+	// Indent of the written objects. Default: two spaces
+	Indent string
+}
+
+func (c StructuredJSON) Format() lokalise.FileFormat { return lokalise.FormatJSONStructured }
+func (c StructuredJSON) Platform() string            { return lokalise.PlatformWeb }
+
+func (c StructuredJSON) separator() string {
+	if c.Separator == "" {
+		return "."
+	}
+	return c.Separator
+}
+
+func (c StructuredJSON) Decode(r io.Reader, langISO string) ([]lokalise.Key, error) {
+	members, err := readJSON(r)
+	if err != nil {
+		return nil, err
+	}
+	var entries []entry
+	if err := c.flatten(&entries, "", members, langISO); err != nil {
+		return nil, err
+	}
+	return keysFromEntries(c.Platform(), entries), nil
+}
+
+func (c StructuredJSON) flatten(entries *[]entry, prefix string, members []jsonMember, langISO string) error {
+	for _, m := range members {
+		name := prefix + m.name
+		obj, ok := m.value.([]jsonMember)
+		if !ok {
+			return fmt.Errorf("formats: structured json value of %q is not an object", name)
+		}
+		i := slices.IndexFunc(obj, func(m jsonMember) bool { return m.name == "translation" })
+		if i < 0 {
+			if err := c.flatten(entries, name+c.separator(), obj, langISO); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var e entry
+		switch v := obj[i].value.(type) {
+		case string:
+			e = singularEntry(name, langISO, v)
+		case []jsonMember:
+			forms, ok := jsonPluralForms(v)
+			if !ok {
+				return fmt.Errorf("formats: structured json translation of %q is not a plural", name)
+			}
+			e = pluralEntry(name, langISO, forms)
+		default:
+			return fmt.Errorf("formats: structured json translation of %q is not a string", name)
+		}
+		for _, a := range obj {
+			s, _ := a.value.(string)
+			switch a.name {
+			case "notes":
+				e.description = s
+			case "context":
+				e.context = s
+			}
+		}
+		*entries = append(*entries, e)
+	}
+	return nil
+}
+
+func (c StructuredJSON) Encode(w io.Writer, langISO string, keys []lokalise.Key) error {
+	var root []jsonMember
+	for _, e := range entriesFromKeys(c.Platform(), keys, langISO) {
+		value := []jsonMember{{"translation", jsonValue(e, langISO)}}
+		if e.description != "" {
+			value = append(value, jsonMember{"notes", e.description})
+		}
+		if e.context != "" {
+			value = append(value, jsonMember{"context", e.context})
+		}
+		if !c.Nested {
+			root = append(root, jsonMember{e.name, value})
+			continue
+		}
+		var err error
+		if root, err = insertJSONMember(root, strings.Split(e.name, c.separator()), value); err != nil {
+			return fmt.Errorf("formats: key %q: %w", e.name, err)
+		}
+	}
+	return writeJSON(w, root, c.Indent)
+}
+
+// ARB is the Application Resource Bundle format of Flutter. Plural values are ICU plural messages,
+// descriptions and contexts are stored in the attributes of the keys.
+type ARB struct {
+	// Indent of the written objects. Default: two spaces
+	Indent string
+}
+
+func (c ARB) Format() lokalise.FileFormat { return lokalise.FormatARB }
+func (c ARB) Platform() string            { return lokalise.PlatformOther }
+
+type arbAttributes struct {
+	Description string `json:"description,omitempty"`
+	Context     string `json:"context,omitempty"`
+}
+
+func (c ARB) Decode(r io.Reader, langISO string) ([]lokalise.Key, error) {
+	members, err := readJSON(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []entry
+	index := make(map[string]int)
+	attributes := make(map[string]arbAttributes)
+	for _, m := range members {
+		if strings.HasPrefix(m.name, "@") {
+			if strings.HasPrefix(m.name, "@@") {
+				continue
+			}
+			if obj, ok := m.value.([]jsonMember); ok {
+				var attrs arbAttributes
+				for _, a := range obj {
+					s, _ := a.value.(string)
+					switch a.name {
+					case "description":
+						attrs.Description = s
+					case "context":
+						attrs.Context = s
+					}
+				}
+				attributes[strings.TrimPrefix(m.name, "@")] = attrs
+			}
+			continue
+		}
+
+		value, ok := m.value.(string)
+		if !ok {
+			return nil, fmt.Errorf("formats: arb value of %q is not a string", m.name)
+		}
+		e := singularEntry(m.name, langISO, value)
+		if name, forms, ok := parseICUPlural(value); ok {
+			e = pluralEntry(m.name, langISO, forms)
+			e.pluralName = name
+		}
+		index[m.name] = len(entries)
+		entries = append(entries, e)
+	}
+	for name, attrs := range attributes {
+		if i, ok := index[name]; ok {
+			entries[i].description, entries[i].context = attrs.Description, attrs.Context
+		}
+	}
+	return keysFromEntries(c.Platform(), entries), nil
+}
+
+func (c ARB) Encode(w io.Writer, langISO string, keys []lokalise.Key) error {
+	root := []jsonMember{{"@@locale", langISO}}
+	for _, e := range entriesFromKeys(c.Platform(), keys, langISO) {
+		value := e.values[langISO]
+		if forms := e.forms(langISO); forms != nil {
+			value = formatICUPlural(e.pluralName, forms)
+		}
+		root = append(root, jsonMember{e.name, value})
+
+		var attrs []jsonMember
+		if e.description != "" {
+			attrs = append(attrs, jsonMember{"description", e.description})
+		}
+		if e.context != "" {
+			attrs = append(attrs, jsonMember{"context", e.context})
+		}
+		if attrs != nil {
+			root = append(root, jsonMember{"@" + e.name, attrs})
+		}
+	}
+	return writeJSON(w, root, c.Indent)
+}
+
+// jsonMember is a member of a JSON object, its value is a string, an object as []jsonMember, or another JSON value.
+type jsonMember struct {
+	name  string
+	value any
+}
+
+// readJSON reads a JSON object, keeping the order of its members.
+func readJSON(r io.Reader) ([]jsonMember, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	t, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("formats: %w", err)
+	}
+	if t != json.Delim('{') {
+		return nil, fmt.Errorf("formats: json file is not an object")
+	}
+	members, err := readJSONObject(dec)
+	if err != nil {
+		return nil, fmt.Errorf("formats: %w", err)
+	}
+	return members, nil
+}
+
+func readJSONObject(dec *json.Decoder) ([]jsonMember, error) {
+	var members []jsonMember
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		name := t.(string)
+
+		t, err = dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value any = t
+		switch t {
+		case json.Delim('{'):
+			if value, err = readJSONObject(dec); err != nil {
+				return nil, err
+			}
+		case json.Delim('['):
+			var v []any
+			for dec.More() {
+				var item any
+				if err := dec.Decode(&item); err != nil {
+					return nil, err
+				}
+				v = append(v, item)
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			value = v
+		}
+		members = append(members, jsonMember{name, value})
+	}
+	_, err := dec.Token() // closing brace
+	return members, err
+}
+
+// jsonPluralForms returns the forms of an object holding only plural forms.
+func jsonPluralForms(members []jsonMember) (lokalise.PluralTranslation, bool) {
+	if len(members) == 0 {
+		return nil, false
+	}
+	forms := make(lokalise.PluralTranslation, len(members))
+	for _, m := range members {
+		s, ok := m.value.(string)
+		if !ok || !isPluralForm(m.name) {
+			return nil, false
+		}
+		forms[m.name] = s
+	}
+	return forms, true
+}
+
+// jsonValue returns the JSON value of an entry, plural forms being an object.
+func jsonValue(e entry, langISO string) any {
+	forms := e.forms(langISO)
+	if forms == nil {
+		return e.values[langISO]
+	}
+	obj := make([]jsonMember, 0, len(forms))
+	for _, f := range forms.Forms() {
+		obj = append(obj, jsonMember{f, forms[f]})
+	}
+	return obj
+}
+
+// insertJSONMember sets the value at a nested path, keeping the order of first insertion.
+func insertJSONMember(members []jsonMember, path []string, value any) ([]jsonMember, error) {
+	for i, m := range members {
+		if m.name != path[0] {
+			continue
+		}
+		obj, ok := m.value.([]jsonMember)
+		if len(path) == 1 || !ok {
+			return nil, fmt.Errorf("conflicts with %q", path[0])
+		}
+		obj, err := insertJSONMember(obj, path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		members[i].value = obj
+		return members, nil
+	}
+
+	if len(path) == 1 {
+		return append(members, jsonMember{path[0], value}), nil
+	}
+	obj, err := insertJSONMember(nil, path[1:], value)
+	if err != nil {
+		return nil, err
+	}
+	return append(members, jsonMember{path[0], obj}), nil
+}
+
+// writeJSON writes an object in member order, without escaping HTML.
+func writeJSON(w io.Writer, members []jsonMember, indent string) error {
+	if indent == "" {
+		indent = "  "
+	}
+	var buf bytes.Buffer
+	if err := writeJSONValue(&buf, members, indent, 0); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func writeJSONValue(buf *bytes.Buffer, value any, indent string, depth int) error {
+	members, ok := value.([]jsonMember)
+	if !ok {
+		return writeJSONScalar(buf, value)
+	}
+	if len(members) == 0 {
+		buf.WriteString("{}")
+		return nil
+	}
+	buf.WriteString("{\n")
+	for i, m := range members {
+		buf.WriteString(strings.Repeat(indent, depth+1))
+		if err := writeJSONScalar(buf, m.name); err != nil {
+			return err
+		}
+		buf.WriteString(": ")
+		if err := writeJSONValue(buf, m.value, indent, depth+1); err != nil {
+			return err
+		}
+		if i < len(members)-1 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('\n')
+	}
+	buf.WriteString(strings.Repeat(indent, depth))
+	buf.WriteByte('}')
+	return nil
+}
+
+func writeJSONScalar(buf *bytes.Buffer, v any) error {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	buf.Write(bytes.TrimSuffix(b.Bytes(), []byte("\n")))
+	return nil
+}
@@ -0,0 +1,307 @@
+package formats
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/lokalise/go-lokalise-api/v5"
+)
+
+// poPluralFormsHeader lists the CLDR forms of the msgstr indexes, so that plurals survive a round trip.
+const poPluralFormsHeader = "X-Lokalise-Plural-Forms"
+
+// PO is the gettext PO format. The msgid is the key name, msgctxt its context and extracted comments (#.)
+// its description. Plural msgstr indexes map to CLDR plural forms, see PluralForms.
+type PO struct {
+	// PluralForms are the CLDR forms of the msgstr indexes. Decoding defaults to the X-Lokalise-Plural-Forms
+	// header written by Encode, then to common forms by number of msgstr. Encoding defaults to the forms in use.
+	PluralForms []string
+}
+
+func (c PO) Format() lokalise.FileFormat { return lokalise.FormatPO }
+func (c PO) Platform() string            { return lokalise.PlatformWeb }
+
+type poMessage struct {
+	comments []string
+	context  *string
+	id       *string
+	idPlural *string
+	str      *string
+	strs     map[int]*string
+	obsolete bool
+}
+
+func (m *poMessage) empty() bool {
+	return m.id == nil && m.context == nil
+}
+
+func (c PO) Decode(r io.Reader, langISO string) ([]lokalise.Key, error) {
+	var (
+		messages []poMessage
+		cur      poMessage
+		field    *string
+	)
+	flush := func() {
+		if !cur.empty() && !cur.obsolete {
+			messages = append(messages, cur)
+		}
+		cur, field = poMessage{}, nil
+	}
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+
+		switch {
+		case text == "":
+			flush()
+			continue
+		case strings.HasPrefix(text, "#~"):
+			cur.obsolete = true
+			continue
+		case strings.HasPrefix(text, "#"):
+			if cur.id != nil && cur.str != nil || cur.strs != nil {
+				flush()
+			}
+			if strings.HasPrefix(text, "#.") {
+				cur.comments = append(cur.comments, strings.TrimSpace(text[2:]))
+			}
+			continue
+		case strings.HasPrefix(text, `"`):
+			if field == nil {
+				return nil, fmt.Errorf("formats: po line %d: string without keyword", line)
+			}
+			s, err := unquotePO(text)
+			if err != nil {
+				return nil, fmt.Errorf("formats: po line %d: %w", line, err)
+			}
+			*field += s
+			continue
+		}
+
+		keyword, rest, _ := strings.Cut(text, " ")
+		value, err := unquotePO(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("formats: po line %d: %w", line, err)
+		}
+		if (keyword == "msgctxt" || keyword == "msgid") && (cur.str != nil || cur.strs != nil) {
+			flush()
+		}
+		field = &value
+		switch {
+		case keyword == "msgctxt":
+			cur.context = field
+		case keyword == "msgid":
+			cur.id = field
+		case keyword == "msgid_plural":
+			cur.idPlural = field
+		case keyword == "msgstr":
+			cur.str = field
+		case strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]"):
+			i, err := strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+			if err != nil {
+				return nil, fmt.Errorf("formats: po line %d: invalid %s", line, keyword)
+			}
+			if cur.strs == nil {
+				cur.strs = make(map[int]*string)
+			}
+			cur.strs[i] = field
+		default:
+			return nil, fmt.Errorf("formats: po line %d: unknown keyword %s", line, keyword)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+
+	forms := c.PluralForms
+	var entries []entry
+	for _, m := range messages {
+		if m.id == nil {
+			continue
+		}
+		if *m.id == "" && m.context == nil {
+			if forms == nil && m.str != nil {
+				forms = poHeaderPluralForms(*m.str)
+			}
+			continue
+		}
+
+		var e entry
+		if m.idPlural != nil || m.strs != nil {
+			n := 0
+			for i := range m.strs {
+				n = max(n, i+1)
+			}
+			mapped := forms
+			if len(mapped) < n {
+				mapped = defaultPOPluralForms(n)
+			}
+			p := make(lokalise.PluralTranslation, len(m.strs))
+			for i, s := range m.strs {
+				p[mapped[i]] = *s
+			}
+			e = pluralEntry(*m.id, langISO, p)
+			if m.idPlural != nil && *m.idPlural != *m.id {
+				e.pluralName = *m.idPlural
+			}
+		} else {
+			var s string
+			if m.str != nil {
+				s = *m.str
+			}
+			e = singularEntry(*m.id, langISO, s)
+		}
+		if m.context != nil {
+			e.context = *m.context
+		}
+		e.description = strings.Join(m.comments, "\n")
+		entries = append(entries, e)
+	}
+	return keysFromEntries(c.Platform(), entries), nil
+}
+
+func (c PO) Encode(w io.Writer, langISO string, keys []lokalise.Key) error {
+	entries := entriesFromKeys(c.Platform(), keys, langISO)
+
+	forms := c.PluralForms
+	if forms == nil {
+		used := make(map[string]bool)
+		for _, e := range entries {
+			for f := range e.forms(langISO) {
+				used[f] = true
+			}
+		}
+		for _, f := range pluralForms {
+			if used[f] {
+				forms = append(forms, f)
+			}
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	header := "Language: " + langISO + "\nMIME-Version: 1.0\nContent-Type: text/plain; charset=UTF-8\nContent-Transfer-Encoding: 8bit\n"
+	if len(forms) > 0 {
+		header += poPluralFormsHeader + ": " + strings.Join(forms, ", ") + "\n"
+	}
+	writePOString(bw, "msgid", "")
+	writePOString(bw, "msgstr", header)
+
+	for _, e := range entries {
+		bw.WriteString("\n")
+		if e.description != "" {
+			for _, line := range strings.Split(e.description, "\n") {
+				bw.WriteString("#. " + line + "\n")
+			}
+		}
+		if e.context != "" {
+			writePOString(bw, "msgctxt", e.context)
+		}
+		writePOString(bw, "msgid", e.name)
+
+		p := e.forms(langISO)
+		if p == nil {
+			writePOString(bw, "msgstr", e.values[langISO])
+			continue
+		}
+		writePOString(bw, "msgid_plural", firstNonEmpty(e.pluralName, e.name))
+		for i, f := range forms {
+			writePOString(bw, "msgstr["+strconv.Itoa(i)+"]", p[f])
+		}
+	}
+	return bw.Flush()
+}
+
+func poHeaderPluralForms(header string) []string {
+	for _, line := range strings.Split(header, "\n") {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(name) != poPluralFormsHeader {
+			continue
+		}
+		var forms []string
+		for _, f := range strings.Split(value, ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				forms = append(forms, f)
+			}
+		}
+		return forms
+	}
+	return nil
+}
+
+// defaultPOPluralForms maps n msgstr indexes to the CLDR forms of the most common languages with n forms.
+func defaultPOPluralForms(n int) []string {
+	switch n {
+	case 1:
+		return []string{"other"}
+	case 2:
+		return []string{"one", "other"}
+	case 3:
+		return []string{"one", "few", "other"}
+	case 4:
+		return []string{"one", "two", "few", "other"}
+	case 5:
+		return []string{"one", "two", "few", "many", "other"}
+	}
+	forms := append([]string(nil), pluralForms...)
+	for i := len(forms); i < n; i++ {
+		forms = append(forms, strconv.Itoa(i))
+	}
+	return forms
+}
+
+func unquotePO(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("invalid string %s", s)
+	}
+	s = s[1 : len(s)-1]
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String(), nil
+}
+
+func quotePO(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// writePOString writes a keyword with its string, splitting multi-line strings after each newline.
+func writePOString(w *bufio.Writer, keyword, s string) {
+	if !strings.Contains(strings.TrimSuffix(s, "\n"), "\n") {
+		w.WriteString(keyword + " " + quotePO(s) + "\n")
+		return
+	}
+	w.WriteString(keyword + " \"\"\n")
+	for _, line := range strings.SplitAfter(s, "\n") {
+		if line != "" {
+			w.WriteString(quotePO(line) + "\n")
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package formats
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/lokalise/go-lokalise-api/v5"
+)
+
+// Properties is the Java .properties format. The comment preceding a key is its description,
+// contexts are not stored and plural forms are written as name[form] keys.
+// Decoding accepts UTF-8 and ISO-8859-1 files.
+type Properties struct {
+	// UTF8 writes non-ASCII characters as is instead of \uXXXX escapes.
+	UTF8 bool
+}
+
+func (c Properties) Format() lokalise.FileFormat { return lokalise.FormatProperties }
+func (c Properties) Platform() string            { return lokalise.PlatformOther }
+
+func (c Properties) Decode(r io.Reader, langISO string) ([]lokalise.Key, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	text := string(data)
+	if !utf8.Valid(data) {
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		text = string(runes)
+	}
+
+	var (
+		entries  []entry
+		comments []string
+		plurals  = make(map[string]int)
+	)
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimLeft(lines[i], " \t\f")
+		switch {
+		case line == "":
+			comments = nil
+			continue
+		case line[0] == '#' || line[0] == '!':
+			comments = append(comments, strings.TrimSpace(line[1:]))
+			continue
+		}
+		for hasContinuation(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+
+		rawName, rawValue := splitProperty(line)
+		name, value := unescapeProperty(rawName), unescapeProperty(rawValue)
+		description := strings.Join(comments, "\n")
+		comments = nil
+
+		if base, form, ok := propertyPluralForm(name); ok {
+			if j, ok := plurals[base]; ok {
+				forms, _ := lokalise.ParsePluralTranslation(entries[j].values[langISO])
+				forms[form] = value
+				entries[j].values[langISO] = forms.String()
+				continue
+			}
+			e := pluralEntry(base, langISO, lokalise.PluralTranslation{form: value})
+			e.description = description
+			plurals[base] = len(entries)
+			entries = append(entries, e)
+			continue
+		}
+		e := singularEntry(name, langISO, value)
+		e.description = description
+		entries = append(entries, e)
+	}
+	return keysFromEntries(c.Platform(), entries), nil
+}
+
+func (c Properties) Encode(w io.Writer, langISO string, keys []lokalise.Key) error {
+	bw := bufio.NewWriter(w)
+	for _, e := range entriesFromKeys(c.Platform(), keys, langISO) {
+		if e.description != "" {
+			for _, line := range strings.Split(e.description, "\n") {
+				bw.WriteString("# " + line + "\n")
+			}
+		}
+		if forms := e.forms(langISO); forms != nil {
+			for _, f := range forms.Forms() {
+				bw.WriteString(c.escape(e.name+"["+f+"]", true) + "=" + c.escape(forms[f], false) + "\n")
+			}
+			continue
+		}
+		bw.WriteString(c.escape(e.name, true) + "=" + c.escape(e.values[langISO], false) + "\n")
+	}
+	return bw.Flush()
+}
+
+func (c Properties) escape(s string, key bool) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\f':
+			b.WriteString(`\f`)
+		case (key || i == 0) && (r == ' ' || r == '=' || r == ':' || r == '#' || r == '!'):
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r > 0x7e && !c.UTF8, r < 0x20:
+			for _, u := range utf16Units(r) {
+				fmt.Fprintf(&b, `\u%04x`, u)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func utf16Units(r rune) []rune {
+	if r < 0x10000 {
+		return []rune{r}
+	}
+	r -= 0x10000
+	return []rune{0xD800 + r>>10, 0xDC00 + r&0x3FF}
+}
+
+// hasContinuation reports whether a line ends with an odd number of backslashes.
+func hasContinuation(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// splitProperty splits a logical line at the first unescaped separator.
+func splitProperty(line string) (name, value string) {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '=', ':', ' ', '\t', '\f':
+			name, value = line[:i], strings.TrimLeft(line[i:], " \t\f")
+			if value != "" && (value[0] == '=' || value[0] == ':') {
+				value = strings.TrimLeft(value[1:], " \t\f")
+			}
+			return name, value
+		}
+	}
+	return line, ""
+}
+
+func unescapeProperty(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var units []uint16
+	var b strings.Builder
+	flush := func() {
+		if units != nil {
+			b.WriteString(string(utf16.Decode(units)))
+			units = nil
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			flush()
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		if s[i] == 'u' && i+4 < len(s) {
+			if u, err := strconv.ParseUint(s[i+1:i+5], 16, 16); err == nil {
+				units = append(units, uint16(u))
+				i += 4
+				continue
+			}
+		}
+		flush()
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	flush()
+	return b.String()
+}
+
+// propertyPluralForm splits a name[form] key written for a plural form.
+func propertyPluralForm(name string) (base, form string, ok bool) {
+	open := strings.LastIndexByte(name, '[')
+	if open <= 0 || !strings.HasSuffix(name, "]") {
+		return "", "", false
+	}
+	form = name[open+1 : len(name)-1]
+	return name[:open], form, isPluralForm(form)
+}
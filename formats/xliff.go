@@ -0,0 +1,405 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/lokalise/go-lokalise-api/v5"
+)
+
+const (
+	xliff12Namespace = "urn:oasis:names:tc:xliff:document:1.2"
+	xliff20Namespace = "urn:oasis:names:tc:xliff:document:2.0"
+
+	xliffDescriptionNote = "description"
+	xliffContextNote     = "context"
+	xliff12PluralGroup   = "x-gettext-plurals"
+	xliff20PluralGroup   = "lokalise:plural"
+)
+
+// XLIFF is the bilingual XLIFF format, version 1.2 or 2.0. Keys are named by the resname (1.2) or name (2.0)
+// of their units, plural keys are groups of units named by plural form. Notes hold descriptions and contexts.
+// Decoding returns the translations of the source language along with langISO, inline markup is read as text.
+type XLIFF struct {
+	// Version of the written files, "1.2" or "2.0". Default: "1.2"
+	Version string
+	// SourceLang is the language of the written sources. The translations of langISO are used if empty.
+	SourceLang string
+	// Original names the written file. Default: "messages"
+	Original string
+}
+
+func (c XLIFF) Format() lokalise.FileFormat { return lokalise.FormatXLIFF }
+func (c XLIFF) Platform() string            { return lokalise.PlatformWeb }
+
+// xmlText is the content of an element, decoded as raw inner XML and encoded as escaped text.
+type xmlText struct {
+	Inner string `xml:",innerxml"`
+}
+
+func (t xmlText) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(t.Inner, start)
+}
+
+type xliffNote struct {
+	From     string `xml:"from,attr,omitempty"`
+	Category string `xml:"category,attr,omitempty"`
+	Text     string `xml:",chardata"`
+}
+
+type xliff12 struct {
+	XMLName xml.Name      `xml:"xliff"`
+	Version string        `xml:"version,attr"`
+	Xmlns   string        `xml:"xmlns,attr,omitempty"`
+	Files   []xliff12File `xml:"file"`
+}
+
+type xliff12File struct {
+	Original   string      `xml:"original,attr"`
+	SourceLang string      `xml:"source-language,attr"`
+	TargetLang string      `xml:"target-language,attr,omitempty"`
+	Datatype   string      `xml:"datatype,attr"`
+	Body       xliff12Body `xml:"body"`
+}
+
+type xliff12Body struct {
+	Units  []xliff12Unit  `xml:"trans-unit"`
+	Groups []xliff12Group `xml:"group"`
+}
+
+type xliff12Unit struct {
+	ID      string      `xml:"id,attr"`
+	ResName string      `xml:"resname,attr,omitempty"`
+	Source  xmlText     `xml:"source"`
+	Target  *xmlText    `xml:"target"`
+	Notes   []xliffNote `xml:"note"`
+}
+
+type xliff12Group struct {
+	ID      string        `xml:"id,attr"`
+	ResName string        `xml:"resname,attr,omitempty"`
+	ResType string        `xml:"restype,attr,omitempty"`
+	Notes   []xliffNote   `xml:"note"`
+	Units   []xliff12Unit `xml:"trans-unit"`
+}
+
+type xliff20 struct {
+	XMLName    xml.Name      `xml:"xliff"`
+	Version    string        `xml:"version,attr"`
+	Xmlns      string        `xml:"xmlns,attr,omitempty"`
+	SourceLang string        `xml:"srcLang,attr"`
+	TargetLang string        `xml:"trgLang,attr,omitempty"`
+	Files      []xliff20File `xml:"file"`
+}
+
+type xliff20File struct {
+	ID     string         `xml:"id,attr"`
+	Units  []xliff20Unit  `xml:"unit"`
+	Groups []xliff20Group `xml:"group"`
+}
+
+type xliff20Unit struct {
+	ID       string           `xml:"id,attr"`
+	Name     string           `xml:"name,attr,omitempty"`
+	Notes    []xliffNote      `xml:"notes>note"`
+	Segments []xliff20Segment `xml:"segment"`
+}
+
+type xliff20Segment struct {
+	Source xmlText  `xml:"source"`
+	Target *xmlText `xml:"target"`
+}
+
+type xliff20Group struct {
+	ID    string        `xml:"id,attr"`
+	Name  string        `xml:"name,attr,omitempty"`
+	Type  string        `xml:"type,attr,omitempty"`
+	Notes []xliffNote   `xml:"notes>note"`
+	Units []xliff20Unit `xml:"unit"`
+}
+
+func (c XLIFF) Decode(r io.Reader, langISO string) ([]lokalise.Key, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var root struct {
+		Version string `xml:"version,attr"`
+	}
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("formats: %w", err)
+	}
+
+	var entries []entry
+	switch root.Version {
+	case "1.2", "1.1", "1.0":
+		entries, err = decodeXLIFF12(data, langISO)
+	case "2.0", "2.1":
+		entries, err = decodeXLIFF20(data, langISO)
+	default:
+		return nil, fmt.Errorf("formats: unsupported xliff version %q", root.Version)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return keysFromEntries(c.Platform(), entries), nil
+}
+
+func decodeXLIFF12(data []byte, langISO string) ([]entry, error) {
+	var doc xliff12
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("formats: %w", err)
+	}
+
+	var entries []entry
+	for _, f := range doc.Files {
+		tr := xliffTranslations{source: f.SourceLang, target: langISO}
+		for _, u := range f.Body.Units {
+			e := entry{name: firstNonEmpty(u.ResName, u.ID), values: make(map[string]string)}
+			if err := tr.set(&e, u.Source, u.Target); err != nil {
+				return nil, err
+			}
+			applyXLIFFNotes(&e, u.Notes)
+			entries = append(entries, e)
+		}
+		for _, g := range f.Body.Groups {
+			if g.ResType != xliff12PluralGroup {
+				continue
+			}
+			e := entry{name: firstNonEmpty(g.ResName, g.ID), plural: true}
+			forms := make(map[string]lokalise.PluralTranslation)
+			for _, u := range g.Units {
+				if err := tr.setForm(forms, firstNonEmpty(u.ResName, u.ID), u.Source, u.Target); err != nil {
+					return nil, err
+				}
+			}
+			e.values = pluralValues(forms)
+			applyXLIFFNotes(&e, g.Notes)
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+func decodeXLIFF20(data []byte, langISO string) ([]entry, error) {
+	var doc xliff20
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("formats: %w", err)
+	}
+
+	tr := xliffTranslations{source: doc.SourceLang, target: langISO}
+	var entries []entry
+	for _, f := range doc.Files {
+		for _, u := range f.Units {
+			e := entry{name: firstNonEmpty(u.Name, u.ID), values: make(map[string]string)}
+			source, target := joinXLIFF20Segments(u.Segments)
+			if err := tr.set(&e, source, target); err != nil {
+				return nil, err
+			}
+			applyXLIFFNotes(&e, u.Notes)
+			entries = append(entries, e)
+		}
+		for _, g := range f.Groups {
+			if g.Type != xliff20PluralGroup {
+				continue
+			}
+			e := entry{name: firstNonEmpty(g.Name, g.ID), plural: true}
+			forms := make(map[string]lokalise.PluralTranslation)
+			for _, u := range g.Units {
+				source, target := joinXLIFF20Segments(u.Segments)
+				if err := tr.setForm(forms, firstNonEmpty(u.Name, u.ID), source, target); err != nil {
+					return nil, err
+				}
+			}
+			e.values = pluralValues(forms)
+			applyXLIFFNotes(&e, g.Notes)
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// xliffTranslations sets the decoded sources and targets of units.
+type xliffTranslations struct {
+	source, target string
+}
+
+func (tr xliffTranslations) set(e *entry, source xmlText, target *xmlText) error {
+	s, err := xmlInnerText(source.Inner)
+	if err != nil {
+		return fmt.Errorf("formats: unit %q: %w", e.name, err)
+	}
+	if tr.source != "" {
+		e.values[tr.source] = s
+	}
+	if target != nil {
+		t, err := xmlInnerText(target.Inner)
+		if err != nil {
+			return fmt.Errorf("formats: unit %q: %w", e.name, err)
+		}
+		e.values[tr.target] = t
+	}
+	return nil
+}
+
+func (tr xliffTranslations) setForm(forms map[string]lokalise.PluralTranslation, form string, source xmlText, target *xmlText) error {
+	e := entry{name: form, values: make(map[string]string)}
+	if err := tr.set(&e, source, target); err != nil {
+		return err
+	}
+	for lang, v := range e.values {
+		if forms[lang] == nil {
+			forms[lang] = make(lokalise.PluralTranslation)
+		}
+		forms[lang][form] = v
+	}
+	return nil
+}
+
+func pluralValues(forms map[string]lokalise.PluralTranslation) map[string]string {
+	values := make(map[string]string, len(forms))
+	for lang, p := range forms {
+		values[lang] = p.String()
+	}
+	return values
+}
+
+func joinXLIFF20Segments(segments []xliff20Segment) (source xmlText, target *xmlText) {
+	for _, s := range segments {
+		source.Inner += s.Source.Inner
+		if s.Target != nil {
+			if target == nil {
+				target = &xmlText{}
+			}
+			target.Inner += s.Target.Inner
+		}
+	}
+	return source, target
+}
+
+func applyXLIFFNotes(e *entry, notes []xliffNote) {
+	for _, n := range notes {
+		switch firstNonEmpty(n.From, n.Category) {
+		case xliffContextNote:
+			e.context = n.Text
+		case "", xliffDescriptionNote:
+			if e.description == "" {
+				e.description = n.Text
+			}
+		}
+	}
+}
+
+func (c XLIFF) Encode(w io.Writer, langISO string, keys []lokalise.Key) error {
+	sourceLang := c.SourceLang
+	if sourceLang == "" {
+		sourceLang = langISO
+	}
+	original := c.Original
+	if original == "" {
+		original = "messages"
+	}
+	entries := entriesFromKeys(c.Platform(), keys, langISO, sourceLang)
+
+	var doc any
+	switch c.Version {
+	case "", "1.2":
+		doc = encodeXLIFF12(entries, original, sourceLang, langISO)
+	case "2.0":
+		doc = encodeXLIFF20(entries, original, sourceLang, langISO)
+	default:
+		return fmt.Errorf("formats: unsupported xliff version %q", c.Version)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func encodeXLIFF12(entries []entry, original, sourceLang, targetLang string) xliff12 {
+	f := xliff12File{Original: original, SourceLang: sourceLang, TargetLang: targetLang, Datatype: "plaintext"}
+	for i, e := range entries {
+		id := strconv.Itoa(i + 1)
+		notes := xliffNotes(e, func(kind, text string) xliffNote { return xliffNote{From: kind, Text: text} })
+		if target := e.forms(targetLang); target != nil {
+			source := e.forms(sourceLang)
+			g := xliff12Group{ID: id, ResName: e.name, ResType: xliff12PluralGroup, Notes: notes}
+			for _, form := range target.Forms() {
+				g.Units = append(g.Units, xliff12Unit{
+					ID:      id + "-" + form,
+					ResName: form,
+					Source:  xmlText{source[form]},
+					Target:  &xmlText{target[form]},
+				})
+			}
+			f.Body.Groups = append(f.Body.Groups, g)
+			continue
+		}
+		f.Body.Units = append(f.Body.Units, xliff12Unit{
+			ID:      id,
+			ResName: e.name,
+			Source:  xmlText{e.values[sourceLang]},
+			Target:  &xmlText{e.values[targetLang]},
+			Notes:   notes,
+		})
+	}
+	return xliff12{Version: "1.2", Xmlns: xliff12Namespace, Files: []xliff12File{f}}
+}
+
+func encodeXLIFF20(entries []entry, original, sourceLang, targetLang string) xliff20 {
+	f := xliff20File{ID: original}
+	for i, e := range entries {
+		id := strconv.Itoa(i + 1)
+		notes := xliffNotes(e, func(kind, text string) xliffNote { return xliffNote{Category: kind, Text: text} })
+		if target := e.forms(targetLang); target != nil {
+			source := e.forms(sourceLang)
+			g := xliff20Group{ID: id, Name: e.name, Type: xliff20PluralGroup, Notes: notes}
+			for _, form := range target.Forms() {
+				g.Units = append(g.Units, xliff20Unit{
+					ID:       id + "-" + form,
+					Name:     form,
+					Segments: []xliff20Segment{{Source: xmlText{source[form]}, Target: &xmlText{target[form]}}},
+				})
+			}
+			f.Groups = append(f.Groups, g)
+			continue
+		}
+		f.Units = append(f.Units, xliff20Unit{
+			ID:       id,
+			Name:     e.name,
+			Notes:    notes,
+			Segments: []xliff20Segment{{Source: xmlText{e.values[sourceLang]}, Target: &xmlText{e.values[targetLang]}}},
+		})
+	}
+	return xliff20{Version: "2.0", Xmlns: xliff20Namespace, SourceLang: sourceLang, TargetLang: targetLang, Files: []xliff20File{f}}
+}
+
+func xliffNotes(e entry, note func(kind, text string) xliffNote) []xliffNote {
+	var notes []xliffNote
+	if e.description != "" {
+		notes = append(notes, note(xliffDescriptionNote, e.description))
+	}
+	if e.context != "" {
+		notes = append(notes, note(xliffContextNote, e.context))
+	}
+	return notes
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,164 @@
+package formats
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lokalise/go-lokalise-api/v5"
+)
+
+// YAML is the nested YAML format, i.e. of Rails. Nested names are joined with dots, plural values are
+// mappings of plural forms and the comment preceding a key is its description. Contexts are not stored.
+type YAML struct {
+	// IncludeRoot nests the keys under the language code, see FileDownload.YAMLIncludeRoot.
+	IncludeRoot bool
+}
+
+func (c YAML) Format() lokalise.FileFormat { return lokalise.FormatYAML }
+func (c YAML) Platform() string            { return lokalise.PlatformWeb }
+
+func (c YAML) Decode(r io.Reader, langISO string) ([]lokalise.Key, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("formats: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("formats: yaml file is not a mapping")
+	}
+	if c.IncludeRoot {
+		if len(root.Content) != 2 || root.Content[1].Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("formats: yaml file has no language root")
+		}
+		root = root.Content[1]
+	}
+
+	var entries []entry
+	if err := flattenYAML(&entries, "", root, langISO); err != nil {
+		return nil, err
+	}
+	return keysFromEntries(c.Platform(), entries), nil
+}
+
+func flattenYAML(entries *[]entry, prefix string, m *yaml.Node, langISO string) error {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		k, v := m.Content[i], m.Content[i+1]
+		name := prefix + k.Value
+		description := yamlComment(k.HeadComment)
+
+		switch v.Kind {
+		case yaml.ScalarNode:
+			e := singularEntry(name, langISO, v.Value)
+			e.description = description
+			*entries = append(*entries, e)
+		case yaml.MappingNode:
+			if forms, ok := yamlPluralForms(v); ok {
+				e := pluralEntry(name, langISO, forms)
+				e.description = description
+				*entries = append(*entries, e)
+				continue
+			}
+			if err := flattenYAML(entries, name+".", v, langISO); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("formats: yaml value of %q is not a string", name)
+		}
+	}
+	return nil
+}
+
+func yamlPluralForms(m *yaml.Node) (lokalise.PluralTranslation, bool) {
+	if len(m.Content) == 0 {
+		return nil, false
+	}
+	forms := make(lokalise.PluralTranslation, len(m.Content)/2)
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		k, v := m.Content[i], m.Content[i+1]
+		if v.Kind != yaml.ScalarNode || !isPluralForm(k.Value) {
+			return nil, false
+		}
+		forms[k.Value] = v.Value
+	}
+	return forms, true
+}
+
+func yamlComment(comment string) string {
+	var lines []string
+	for _, line := range strings.Split(comment, "\n") {
+		if line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "#")); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (c YAML) Encode(w io.Writer, langISO string, keys []lokalise.Key) error {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	for _, e := range entriesFromKeys(c.Platform(), keys, langISO) {
+		value := yamlString(e.values[langISO])
+		if forms := e.forms(langISO); forms != nil {
+			value = &yaml.Node{Kind: yaml.MappingNode}
+			for _, f := range forms.Forms() {
+				value.Content = append(value.Content, yamlString(f), yamlString(forms[f]))
+			}
+		}
+		if err := insertYAML(root, strings.Split(e.name, "."), value, e.description); err != nil {
+			return fmt.Errorf("formats: key %q: %w", e.name, err)
+		}
+	}
+	if c.IncludeRoot {
+		root = &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{yamlString(langISO), root}}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func insertYAML(m *yaml.Node, path []string, value *yaml.Node, description string) error {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value != path[0] {
+			continue
+		}
+		child := m.Content[i+1]
+		if len(path) == 1 || child.Kind != yaml.MappingNode {
+			return fmt.Errorf("conflicts with %q", path[0])
+		}
+		return insertYAML(child, path[1:], value, description)
+	}
+
+	key := yamlString(path[0])
+	if len(path) == 1 {
+		if description != "" {
+			key.HeadComment = "# " + strings.ReplaceAll(description, "\n", "\n# ")
+		}
+		m.Content = append(m.Content, key, value)
+		return nil
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	m.Content = append(m.Content, key, child)
+	return insertYAML(child, path[1:], value, description)
+}
+
+func yamlString(s string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+}
@@ -3,6 +3,8 @@ module github.com/lokalise/go-lokalise-api/v5
 require (
 	github.com/go-resty/resty/v2 v2.16.5
 	github.com/google/go-querystring v1.1.0
+	github.com/rivo/uniseg v0.4.7
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require golang.org/x/net v0.39.0 // indirect
@@ -0,0 +1,218 @@
+package lokalise
+
+import (
+	"context"
+	"fmt"
+)
+
+const defaultMachineBatchSize = 50
+
+// MachineTranslator translates texts from one language to another.
+// Implementations must return one translation per text, in the same order.
+type MachineTranslator interface {
+	Translate(ctx context.Context, sourceLang, targetLang string, texts []string) ([]string, error)
+}
+
+// MachineTranslatorFunc adapts a function to the MachineTranslator interface.
+type MachineTranslatorFunc func(ctx context.Context, sourceLang, targetLang string, texts []string) ([]string, error)
+
+func (f MachineTranslatorFunc) Translate(ctx context.Context, sourceLang, targetLang string, texts []string) ([]string, error) {
+	return f(ctx, sourceLang, targetLang, texts)
+}
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service request/response objects
+// _____________________________________________________________________________________________________________________
+
+type MachineFillOptions struct {
+	// SourceLang is the language translated from, the project base language if empty.
+	SourceLang string
+	// Languages are the languages to fill, every project language but SourceLang if empty.
+	Languages []string
+	// CustomTranslationStatusIDs are set on the filled translations, i.e. a "machine translated" status.
+	CustomTranslationStatusIDs []string
+	// BatchSize is the number of texts sent to the translator at once. Default: 50
+	BatchSize int
+	// Bulk configures the updates of the translations.
+	Bulk BulkTranslationUpdateOptions
+}
+
+type MachineFillReport struct {
+	// Results of the translation updates, per language in the order of MachineFillOptions.Languages.
+	Results []TranslationUpdateResult
+	// Skipped counts the untranslated translations without a source text.
+	Skipped int
+}
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service methods
+// _____________________________________________________________________________________________________________________
+
+// MachineFill fills the untranslated translations of a project with machine translations of the source language.
+// Filled translations are marked unverified. Plural translations are translated form by form, using the
+// plural forms of the target language. Failed updates are reported in the results as with BulkUpdate.
+func (c *TranslationService) MachineFill(projectID string, translator MachineTranslator, opts MachineFillOptions) (r MachineFillReport, err error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultMachineBatchSize
+	}
+
+	if opts.SourceLang == "" {
+		ps := ProjectService{BaseService: c.BaseService}
+		project, err := ps.Retrieve(projectID)
+		if err != nil {
+			return r, err
+		}
+		opts.SourceLang = project.BaseLangISO
+	}
+
+	ls := LanguageService{c.BaseService}
+	projectLangs, err := ls.listAll(projectID)
+	if err != nil {
+		return
+	}
+	langs := make(map[string]Language, len(projectLangs))
+	for _, l := range projectLangs {
+		langs[l.LangISO] = l
+	}
+
+	source, ok := langs[opts.SourceLang]
+	if !ok {
+		return r, fmt.Errorf("lokalise: source language %s is not a project language", opts.SourceLang)
+	}
+	if len(opts.Languages) == 0 {
+		for _, l := range sortedLanguageISOs(langs) {
+			if l != opts.SourceLang {
+				opts.Languages = append(opts.Languages, l)
+			}
+		}
+	}
+
+	sourceTranslations, err := c.listAll(projectID, TranslationListOptions{FilterLangID: source.LangID})
+	if err != nil {
+		return
+	}
+	sourceTexts := make(map[int64]string, len(sourceTranslations))
+	for _, t := range sourceTranslations {
+		sourceTexts[t.KeyID] = t.Translation
+	}
+
+	var updates []TranslationUpdate
+	for _, iso := range opts.Languages {
+		target, ok := langs[iso]
+		if !ok {
+			return r, fmt.Errorf("lokalise: language %s is not a project language", iso)
+		}
+
+		untranslated, err := c.listAll(projectID, TranslationListOptions{FilterLangID: target.LangID, FilterUntranslated: 1})
+		if err != nil {
+			return r, err
+		}
+
+		fill := newMachineFill(target)
+		for _, t := range untranslated {
+			if !fill.add(t, sourceTexts[t.KeyID]) {
+				r.Skipped++
+			}
+		}
+		if err := fill.translate(c.Ctx(), translator, opts.SourceLang, opts.BatchSize); err != nil {
+			return r, err
+		}
+
+		for _, u := range fill.updates() {
+			u.Update.IsUnverified = Bool(true)
+			u.Update.CustomTranslationStatusIDs = opts.CustomTranslationStatusIDs
+			updates = append(updates, u)
+		}
+	}
+
+	r.Results, err = c.BulkUpdate(projectID, updates, opts.Bulk)
+	return r, err
+}
+
+// machineFill collects the texts to translate into one language, plural forms being separate texts.
+type machineFill struct {
+	lang  Language
+	texts []string
+	items []machineFillItem
+}
+
+type machineFillItem struct {
+	translationID int64
+	// forms are the plural forms of the texts, empty for regular translations
+	forms []string
+	// first is the index of the first text of the item
+	first int
+}
+
+func newMachineFill(lang Language) *machineFill {
+	return &machineFill{lang: lang}
+}
+
+// add queues a translation, it returns false if there is nothing to translate.
+func (f *machineFill) add(t Translation, source string) bool {
+	if source == "" {
+		return false
+	}
+	item := machineFillItem{translationID: t.TranslationID, first: len(f.texts)}
+
+	if isPluralValue(source) {
+		sourceForms, _ := ParsePluralTranslation(source)
+		forms := f.lang.PluralForms
+		if len(forms) == 0 {
+			forms = sourceForms.Forms()
+		}
+		for _, form := range forms {
+			text, ok := sourceForms[form]
+			if !ok {
+				text = sourceForms["other"]
+			}
+			item.forms = append(item.forms, form)
+			f.texts = append(f.texts, text)
+		}
+	} else {
+		f.texts = append(f.texts, source)
+	}
+
+	f.items = append(f.items, item)
+	return true
+}
+
+// translate replaces the queued texts with their translations.
+func (f *machineFill) translate(ctx context.Context, translator MachineTranslator, sourceLang string, batchSize int) error {
+	for start := 0; start < len(f.texts); start += batchSize {
+		end := min(start+batchSize, len(f.texts))
+		translated, err := translator.Translate(ctx, sourceLang, f.lang.LangISO, f.texts[start:end])
+		if err != nil {
+			return fmt.Errorf("lokalise: machine translation to %s: %w", f.lang.LangISO, err)
+		}
+		if len(translated) != end-start {
+			return fmt.Errorf("lokalise: machine translation to %s returned %d texts instead of %d", f.lang.LangISO, len(translated), end-start)
+		}
+		copy(f.texts[start:end], translated)
+	}
+	return nil
+}
+
+func (f *machineFill) updates() []TranslationUpdate {
+	updates := make([]TranslationUpdate, len(f.items))
+	for i, item := range f.items {
+		value := f.texts[item.first]
+		if item.forms != nil {
+			p := make(PluralTranslation, len(item.forms))
+			for j, form := range item.forms {
+				p[form] = f.texts[item.first+j]
+			}
+			value = p.String()
+		}
+		updates[i] = TranslationUpdate{TranslationID: item.translationID, Update: UpdateTranslation{Translation: value}}
+	}
+	return updates
+}
+
+func sortedLanguageISOs(langs map[string]Language) []string {
+	set := make(map[string]bool, len(langs))
+	for iso := range langs {
+		set[iso] = true
+	}
+	return sortedSet(set)
+}
@@ -0,0 +1,148 @@
+package lokalise
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestPseudoTranslator_Translate(t *testing.T) {
+	got, err := PseudoTranslator{}.Translate(context.Background(), "en", "fr", []string{"Save", "OK 42"})
+	if err != nil {
+		t.Fatalf("Translate returned error: %v", err)
+	}
+	if want := []string{"[Šåṽé]", "[ÖĶ 42]"}; !reflect.DeepEqual(got, want) {
+		t.Errorf(assertionTemplate, "Translate", got, want)
+	}
+}
+
+func TestTranslationService_MachineFill(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			_, _ = fmt.Fprint(w, `{"project_id": "`+testProjectID+`", "base_language_iso": "en"}`)
+		})
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/languages", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			_, _ = fmt.Fprint(w, `{"languages": [
+				{"lang_id": 1, "lang_iso": "en", "plural_forms": ["one", "other"]},
+				{"lang_id": 2, "lang_iso": "pl", "plural_forms": ["one", "few", "many", "other"]}
+			]}`)
+		})
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/translations", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			q := r.URL.Query()
+			switch q.Get("filter_lang_id") {
+			case "1":
+				_, _ = fmt.Fprint(w, `{"translations": [
+					{"translation_id": 11, "key_id": 1, "language_iso": "en", "translation": "Save"},
+					{"translation_id": 12, "key_id": 2, "language_iso": "en", "translation": "{\"one\": \"a file\", \"other\": \"files\"}"}
+				]}`)
+			case "2":
+				if q.Get("filter_untranslated") != "1" {
+					t.Errorf("filter_untranslated = %q, want 1", q.Get("filter_untranslated"))
+				}
+				_, _ = fmt.Fprint(w, `{"translations": [
+					{"translation_id": 21, "key_id": 1, "language_iso": "pl", "translation": ""},
+					{"translation_id": 22, "key_id": 2, "language_iso": "pl", "translation": ""},
+					{"translation_id": 23, "key_id": 3, "language_iso": "pl", "translation": ""}
+				]}`)
+			default:
+				t.Errorf("unexpected filter_lang_id %q", q.Get("filter_lang_id"))
+			}
+		})
+
+	var (
+		mu     sync.Mutex
+		bodies = make(map[string]string)
+	)
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/translations/", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "PUT")
+			id := strings.TrimPrefix(r.URL.Path, fmt.Sprintf("/projects/%s/translations/", testProjectID))
+			b, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			bodies[id] = string(b)
+			mu.Unlock()
+			_, _ = fmt.Fprintf(w, `{"translation": {"translation_id": %s}}`, id)
+		})
+
+	var calls int
+	translator := MachineTranslatorFunc(func(ctx context.Context, sourceLang, targetLang string, texts []string) ([]string, error) {
+		calls++
+		if sourceLang != "en" || targetLang != "pl" {
+			t.Errorf("Translate(%s, %s), want en to pl", sourceLang, targetLang)
+		}
+		return PseudoTranslator{}.Translate(ctx, sourceLang, targetLang, texts)
+	})
+
+	r, err := client.Translations().MachineFill(testProjectID, translator, MachineFillOptions{
+		CustomTranslationStatusIDs: []string{"7"},
+		BatchSize:                  2,
+	})
+	if err != nil {
+		t.Fatalf("Translations.MachineFill returned error: %v", err)
+	}
+	if r.Skipped != 1 || len(r.Results) != 2 {
+		t.Errorf(assertionTemplate, "MachineFill report", r, "2 results, 1 skipped")
+	}
+	if calls != 3 {
+		t.Errorf(assertionTemplate, "translator calls", calls, 3)
+	}
+
+	want := map[string]string{
+		"21": `{"translation":"[Šåṽé]","is_unverified":true,"custom_translation_status_ids":["7"]}`,
+		"22": `{"translation":{"few":"[ƒîļéš]","many":"[ƒîļéš]","one":"[å ƒîļé]","other":"[ƒîļéš]"},"is_unverified":true,"custom_translation_status_ids":["7"]}`,
+	}
+	if !reflect.DeepEqual(bodies, want) {
+		t.Errorf(assertionTemplate, "update bodies", bodies, want)
+	}
+}
+
+func TestTranslationService_MachineFill_TranslatorError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/languages", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"languages": [{"lang_id": 1, "lang_iso": "en"}, {"lang_id": 2, "lang_iso": "de"}]}`)
+		})
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/translations", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"translations": [{"translation_id": 1, "key_id": 1, "translation": "Save"}]}`)
+		})
+
+	failure := errors.New("quota exceeded")
+	translator := MachineTranslatorFunc(func(context.Context, string, string, []string) ([]string, error) {
+		return nil, failure
+	})
+	_, err := client.Translations().MachineFill(testProjectID, translator, MachineFillOptions{SourceLang: "en"})
+	if !errors.Is(err, failure) {
+		t.Errorf(assertionTemplate, "MachineFill error", err, failure)
+	}
+}
@@ -87,3 +87,18 @@ func headerInt64(headers http.Header, headerKey string) int64 {
 	}
 	return value
 }
+
+// walkOffsetPages calls fetch for every page of an offset paginated list, starting with the first one.
+// The walk stops after the last page reported by the pagination headers, or after the first page when
+// the headers are missing.
+func walkOffsetPages(fetch func(page uint) (Paged, error)) error {
+	for page := uint(1); ; page++ {
+		paged, err := fetch(page)
+		if err != nil {
+			return err
+		}
+		if paged.PageCount < 0 || int64(page) >= paged.PageCount {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package lokalise
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PluralTranslation is the value of a plural key translation, indexed by plural form,
+// i.e. zero, one, two, few, many and other.
+type PluralTranslation map[string]string
+
+// pluralFormsOrder is the CLDR order of plural forms.
+var pluralFormsOrder = []string{"zero", "one", "two", "few", "many", "other"}
+
+// PluralFormsError is returned when a plural translation does not match the plural forms of a language.
+type PluralFormsError struct {
+	LangISO string
+	Unknown []string
+	Missing []string
+}
+
+func (e PluralFormsError) Error() string {
+	var problems []string
+	if len(e.Unknown) > 0 {
+		problems = append(problems, "unknown forms "+strings.Join(e.Unknown, ", "))
+	}
+	if len(e.Missing) > 0 {
+		problems = append(problems, "missing forms "+strings.Join(e.Missing, ", "))
+	}
+	return fmt.Sprintf("lokalise: invalid plural translation for %s: %s", e.LangISO, strings.Join(problems, "; "))
+}
+
+// ParsePluralTranslation decodes the JSON object of plural forms stored in a translation.
+func ParsePluralTranslation(s string) (PluralTranslation, error) {
+	var p PluralTranslation
+	if err := json.Unmarshal([]byte(s), &p); err != nil {
+		return nil, fmt.Errorf("lokalise: translation is not a plural object: %w", err)
+	}
+	return p, nil
+}
+
+// String returns the JSON encoding expected by the API.
+func (p PluralTranslation) String() string {
+	if p == nil {
+		return "{}"
+	}
+	data, _ := json.Marshal(map[string]string(p))
+	return string(data)
+}
+
+// Forms returns the plural forms set in p, known forms first in CLDR order.
+func (p PluralTranslation) Forms() []string {
+	forms := make([]string, 0, len(p))
+	for _, f := range pluralFormsOrder {
+		if _, ok := p[f]; ok {
+			forms = append(forms, f)
+		}
+	}
+	var other []string
+	for f := range p {
+		if pluralFormIndex(f) < 0 {
+			other = append(other, f)
+		}
+	}
+	sort.Strings(other)
+	return append(forms, other...)
+}
+
+// Validate checks that p sets exactly the plural forms of the language.
+func (p PluralTranslation) Validate(lang Language) error {
+	allowed := make(map[string]bool, len(lang.PluralForms))
+	for _, f := range lang.PluralForms {
+		allowed[f] = true
+	}
+
+	e := PluralFormsError{LangISO: lang.LangISO}
+	for _, f := range p.Forms() {
+		if !allowed[f] {
+			e.Unknown = append(e.Unknown, f)
+		}
+	}
+	for _, f := range lang.PluralForms {
+		if _, ok := p[f]; !ok {
+			e.Missing = append(e.Missing, f)
+		}
+	}
+	if len(e.Unknown) > 0 || len(e.Missing) > 0 {
+		return e
+	}
+	return nil
+}
+
+// IsPluralValue reports whether the translation value holds a JSON object of plural forms.
+func (t Translation) IsPluralValue() bool {
+	return isPluralValue(t.Translation)
+}
+
+// Plural decodes the plural forms of the translation of a plural key.
+func (t Translation) Plural() (PluralTranslation, error) {
+	return ParsePluralTranslation(t.Translation)
+}
+
+func (t NewTranslation) Plural() (PluralTranslation, error) {
+	return ParsePluralTranslation(t.Translation)
+}
+
+func (t *NewTranslation) SetPlural(p PluralTranslation) {
+	t.Translation = p.String()
+}
+
+func (t UpdateTranslation) Plural() (PluralTranslation, error) {
+	return ParsePluralTranslation(t.Translation)
+}
+
+func (t *UpdateTranslation) SetPlural(p PluralTranslation) {
+	t.Translation = p.String()
+}
+
+func (r SegmentUpdateRequest) Plural() (PluralTranslation, error) {
+	return ParsePluralTranslation(r.Value)
+}
+
+func (r *SegmentUpdateRequest) SetPlural(p PluralTranslation) {
+	r.Value = p.String()
+}
+
+func isPluralValue(s string) bool {
+	if !strings.HasPrefix(strings.TrimSpace(s), "{") {
+		return false
+	}
+	var p map[string]string
+	return json.Unmarshal([]byte(s), &p) == nil
+}
+
+func pluralFormIndex(form string) int {
+	for i, f := range pluralFormsOrder {
+		if f == form {
+			return i
+		}
+	}
+	return -1
+}
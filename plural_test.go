@@ -0,0 +1,83 @@
+package lokalise
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestTranslation_Plural(t *testing.T) {
+	tr := Translation{Translation: `{"one":"1 apple","other":"%d apples"}`}
+	if !tr.IsPluralValue() {
+		t.Errorf("Translation.IsPluralValue returned false")
+	}
+
+	p, err := tr.Plural()
+	if err != nil {
+		t.Errorf("Translation.Plural returned error: %v", err)
+	}
+	want := PluralTranslation{"one": "1 apple", "other": "%d apples"}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf(assertionTemplate, "Translation.Plural", p, want)
+	}
+
+	if (Translation{Translation: "{apples}"}).IsPluralValue() {
+		t.Errorf("Translation.IsPluralValue returned true for a plain string")
+	}
+	if _, err := (Translation{Translation: "apples"}).Plural(); err == nil {
+		t.Errorf("Translation.Plural returned no error for a plain string")
+	}
+}
+
+func TestPluralTranslation_Validate(t *testing.T) {
+	lang := Language{LangISO: "lv", PluralForms: []string{"zero", "one", "other"}}
+
+	if err := (PluralTranslation{"zero": "0", "one": "1", "other": "n"}).Validate(lang); err != nil {
+		t.Errorf("PluralTranslation.Validate returned error: %v", err)
+	}
+
+	err := PluralTranslation{"one": "1", "few": "n", "other": "n"}.Validate(lang)
+	want := PluralFormsError{LangISO: "lv", Unknown: []string{"few"}, Missing: []string{"zero"}}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf(assertionTemplate, "PluralTranslation.Validate", err, want)
+	}
+	if got := err.Error(); got != "lokalise: invalid plural translation for lv: unknown forms few; missing forms zero" {
+		t.Errorf("PluralFormsError.Error returned %q", got)
+	}
+}
+
+func TestSegmentationService_Update_Plural(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/keys/%d/segments/%s/%d", testProjectID, 640, "en", 1),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "PUT")
+			data := `{
+				"value": {
+					"one": "One fox",
+					"other": "Many foxes"
+				}
+			}`
+
+			req := new(bytes.Buffer)
+			_ = json.Compact(req, []byte(data))
+
+			testBody(t, r, req.String())
+
+			_, _ = fmt.Fprint(w, `{"project_id": "`+testProjectID+`"}`)
+		})
+
+	req := SegmentUpdateRequest{}
+	req.SetPlural(PluralTranslation{"one": "One fox", "other": "Many foxes"})
+
+	_, err := client.Segments().Update(testProjectID, 640, "en", 1, req)
+	if err != nil {
+		t.Errorf("Segments.Update returned error: %v", err)
+	}
+}
@@ -0,0 +1,242 @@
+package lokalise
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// HistoryChangeKind is the kind of a change between two captures.
+type HistoryChangeKind string
+
+const (
+	HistoryAdded   HistoryChangeKind = "added"
+	HistoryRemoved HistoryChangeKind = "removed"
+	HistoryChanged HistoryChangeKind = "changed"
+)
+
+// ProjectCapture is the state of the translations of a project at some point, i.e. taken from the live
+// project or from a snapshot. It is JSON serializable, so captures can be stored and compared later.
+type ProjectCapture struct {
+	WithProjectID
+	// SnapshotID is set for captures of snapshots.
+	SnapshotID int64     `json:"snapshot_id,omitempty"`
+	CapturedAt time.Time `json:"captured_at"`
+	// Keys are listed with their translations.
+	Keys []Key `json:"keys"`
+}
+
+// HistoryChange is a translation added, removed or changed between two captures.
+// Strings are non-empty translations, emptying a translation removes the string.
+type HistoryChange struct {
+	Kind    HistoryChangeKind
+	LangISO string
+	KeyName string
+	Before  string
+	After   string
+	// ModifiedBy, ModifiedByEmail and ModifiedAtTs describe the last modification of the translation.
+	// They are the ones of the before translation for removed strings, which deletion is not recorded.
+	ModifiedBy      int64
+	ModifiedByEmail string
+	ModifiedAtTs    int64
+}
+
+// Editor returns the email of the editor of the change, or its user ID if the email is unknown.
+func (c HistoryChange) Editor() string {
+	if c.ModifiedByEmail != "" {
+		return c.ModifiedByEmail
+	}
+	if c.ModifiedBy != 0 {
+		return strconv.FormatInt(c.ModifiedBy, 10)
+	}
+	return ""
+}
+
+type HistoryCounts struct {
+	Added   int
+	Removed int
+	Changed int
+}
+
+func (c *HistoryCounts) add(kind HistoryChangeKind) {
+	switch kind {
+	case HistoryAdded:
+		c.Added++
+	case HistoryRemoved:
+		c.Removed++
+	case HistoryChanged:
+		c.Changed++
+	}
+}
+
+// HistoryReport lists the changes sorted by language and key name, and counts them per language
+// and per editor (see HistoryChange.Editor).
+type HistoryReport struct {
+	Changes    []HistoryChange
+	ByLanguage map[string]HistoryCounts
+	ByEditor   map[string]HistoryCounts
+}
+
+type HistoryOptions struct {
+	// Platform selects the key names identifying keys across captures, PlatformOther if empty.
+	// Key IDs are not used as projects restored from snapshots have their own IDs.
+	Platform string
+	// Since excludes added and changed strings modified before the unix timestamp.
+	Since int64
+}
+
+// Diff reports the changes between c, the earlier capture, and later.
+func (c *ProjectCapture) Diff(later *ProjectCapture, opts HistoryOptions) HistoryReport {
+	before, after := c.translationIndex(opts.Platform), later.translationIndex(opts.Platform)
+
+	var changes []HistoryChange
+	for id, b := range before {
+		a, ok := after[id]
+		switch {
+		case !ok:
+			changes = append(changes, newHistoryChange(HistoryRemoved, id, b, Translation{}, b))
+		case a.Translation != b.Translation && a.ModifiedAtTs >= opts.Since:
+			changes = append(changes, newHistoryChange(HistoryChanged, id, b, a, a))
+		}
+	}
+	for id, a := range after {
+		if _, ok := before[id]; !ok && a.ModifiedAtTs >= opts.Since {
+			changes = append(changes, newHistoryChange(HistoryAdded, id, Translation{}, a, a))
+		}
+	}
+	return newHistoryReport(changes)
+}
+
+// ChangedSince reports the strings of the capture modified since the unix timestamp as changed,
+// i.e. to review the changes of the live project without an earlier capture.
+func (c *ProjectCapture) ChangedSince(since int64, platform string) HistoryReport {
+	var changes []HistoryChange
+	for id, t := range c.translationIndex(platform) {
+		if t.ModifiedAtTs >= since {
+			changes = append(changes, newHistoryChange(HistoryChanged, id, Translation{}, t, t))
+		}
+	}
+	return newHistoryReport(changes)
+}
+
+type historyStringID struct {
+	langISO string
+	keyName string
+}
+
+// translationIndex indexes the non-empty translations by language and key name.
+func (c *ProjectCapture) translationIndex(platform string) map[historyStringID]Translation {
+	if platform == "" {
+		platform = PlatformOther
+	}
+	m := make(map[historyStringID]Translation)
+	for _, k := range c.Keys {
+		name := k.KeyName.For(platform)
+		if name == "" {
+			continue
+		}
+		for _, t := range k.Translations {
+			if t.Translation != "" {
+				m[historyStringID{t.LanguageISO, name}] = t
+			}
+		}
+	}
+	return m
+}
+
+func newHistoryChange(kind HistoryChangeKind, id historyStringID, before, after, modified Translation) HistoryChange {
+	return HistoryChange{
+		Kind:            kind,
+		LangISO:         id.langISO,
+		KeyName:         id.keyName,
+		Before:          before.Translation,
+		After:           after.Translation,
+		ModifiedBy:      modified.ModifiedBy,
+		ModifiedByEmail: modified.ModifiedByEmail,
+		ModifiedAtTs:    modified.ModifiedAtTs,
+	}
+}
+
+func newHistoryReport(changes []HistoryChange) HistoryReport {
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.LangISO != b.LangISO {
+			return a.LangISO < b.LangISO
+		}
+		return a.KeyName < b.KeyName
+	})
+
+	r := HistoryReport{
+		Changes:    changes,
+		ByLanguage: make(map[string]HistoryCounts),
+		ByEditor:   make(map[string]HistoryCounts),
+	}
+	for _, ch := range changes {
+		lang, editor := r.ByLanguage[ch.LangISO], r.ByEditor[ch.Editor()]
+		lang.add(ch.Kind)
+		editor.add(ch.Kind)
+		r.ByLanguage[ch.LangISO], r.ByEditor[ch.Editor()] = lang, editor
+	}
+	return r
+}
+
+// Capture fetches the keys of the live project with their translations.
+func (c *KeyService) Capture(projectID string) (*ProjectCapture, error) {
+	capturedAt := time.Now().UTC()
+	keys, err := c.listAll(projectID, KeyListOptions{IncludeTranslations: 1})
+	if err != nil {
+		return nil, err
+	}
+	return &ProjectCapture{
+		WithProjectID: WithProjectID{ProjectID: projectID},
+		CapturedAt:    capturedAt,
+		Keys:          keys,
+	}, nil
+}
+
+// Capture captures a snapshot of the project. Snapshots can only be read by restoring them,
+// so the snapshot is restored into a temporary project which is deleted once captured.
+// CapturedAt is the creation time of the snapshot.
+func (c *SnapshotService) Capture(projectID string, snapshotID int64) (*ProjectCapture, error) {
+	var snapshot *Snapshot
+	s := *c
+	s.Limit = maxSnapshotsPageLimit
+	err := walkOffsetPages(func(page uint) (Paged, error) {
+		s.Page = page
+		resp, err := s.List(projectID)
+		for i := range resp.Snapshots {
+			if resp.Snapshots[i].SnapshotID == snapshotID {
+				snapshot = &resp.Snapshots[i]
+			}
+		}
+		return resp.Paged, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, fmt.Errorf("lokalise: snapshot %d not found in project %s", snapshotID, projectID)
+	}
+
+	restored, err := c.Restore(projectID, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	ks := KeyService{BaseService: c.BaseService}
+	capture, err := ks.Capture(restored.ProjectID)
+
+	ps := ProjectService{BaseService: c.BaseService}
+	if _, delErr := ps.Delete(restored.ProjectID); delErr != nil && err == nil {
+		err = fmt.Errorf("lokalise: delete project %s restored from snapshot %d: %w", restored.ProjectID, snapshotID, delErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	capture.ProjectID = projectID
+	capture.SnapshotID = snapshotID
+	capture.CapturedAt = time.Unix(snapshot.CreatedAtTs, 0).UTC()
+	return capture, nil
+}
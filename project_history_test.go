@@ -0,0 +1,141 @@
+package lokalise
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func historyKey(id int64, name string, translations ...Translation) Key {
+	return Key{KeyID: id, KeyName: PlatformStrings{Ios: name, Android: name, Web: name, Other: name}, Translations: translations}
+}
+
+func TestProjectCapture_Diff(t *testing.T) {
+	before := &ProjectCapture{Keys: []Key{
+		historyKey(1, "title",
+			Translation{LanguageISO: "en", Translation: "Title", ModifiedByEmail: "ann@example.com", ModifiedAtTs: 10},
+			Translation{LanguageISO: "de", Translation: "Titel", ModifiedByEmail: "bob@example.com", ModifiedAtTs: 10},
+		),
+		historyKey(2, "removed", Translation{LanguageISO: "en", Translation: "Gone", ModifiedBy: 7, ModifiedAtTs: 10}),
+	}}
+	// restored snapshots have other key IDs
+	after := &ProjectCapture{Keys: []Key{
+		historyKey(11, "title",
+			Translation{LanguageISO: "en", Translation: "Title", ModifiedByEmail: "ann@example.com", ModifiedAtTs: 10},
+			Translation{LanguageISO: "de", Translation: "Überschrift", ModifiedByEmail: "bob@example.com", ModifiedAtTs: 30},
+		),
+		historyKey(12, "added",
+			Translation{LanguageISO: "en", Translation: "New", ModifiedByEmail: "ann@example.com", ModifiedAtTs: 20},
+			Translation{LanguageISO: "de", Translation: ""},
+		),
+	}}
+
+	r := before.Diff(after, HistoryOptions{})
+	want := []HistoryChange{
+		{Kind: HistoryChanged, LangISO: "de", KeyName: "title", Before: "Titel", After: "Überschrift", ModifiedByEmail: "bob@example.com", ModifiedAtTs: 30},
+		{Kind: HistoryAdded, LangISO: "en", KeyName: "added", After: "New", ModifiedByEmail: "ann@example.com", ModifiedAtTs: 20},
+		{Kind: HistoryRemoved, LangISO: "en", KeyName: "removed", Before: "Gone", ModifiedBy: 7, ModifiedAtTs: 10},
+	}
+	if !reflect.DeepEqual(r.Changes, want) {
+		t.Errorf(assertionTemplate, "Diff changes", r.Changes, want)
+	}
+
+	wantLangs := map[string]HistoryCounts{"de": {Changed: 1}, "en": {Added: 1, Removed: 1}}
+	if !reflect.DeepEqual(r.ByLanguage, wantLangs) {
+		t.Errorf(assertionTemplate, "Diff by language", r.ByLanguage, wantLangs)
+	}
+	wantEditors := map[string]HistoryCounts{"bob@example.com": {Changed: 1}, "ann@example.com": {Added: 1}, "7": {Removed: 1}}
+	if !reflect.DeepEqual(r.ByEditor, wantEditors) {
+		t.Errorf(assertionTemplate, "Diff by editor", r.ByEditor, wantEditors)
+	}
+
+	r = before.Diff(after, HistoryOptions{Since: 25})
+	if len(r.Changes) != 2 || r.Changes[0].Kind != HistoryChanged || r.Changes[1].Kind != HistoryRemoved {
+		t.Errorf(assertionTemplate, "Diff since", r.Changes, "changed and removed")
+	}
+
+	r = after.ChangedSince(20, PlatformOther)
+	if got := r.ByEditor; !reflect.DeepEqual(got, map[string]HistoryCounts{"bob@example.com": {Changed: 1}, "ann@example.com": {Changed: 1}}) {
+		t.Errorf(assertionTemplate, "ChangedSince", got, "2 changes")
+	}
+}
+
+func TestProjectCapture_JSON(t *testing.T) {
+	c := &ProjectCapture{
+		WithProjectID: WithProjectID{ProjectID: testProjectID},
+		CapturedAt:    time.Unix(100, 0).UTC(),
+		Keys:          []Key{historyKey(1, "title", Translation{LanguageISO: "en", Translation: "Title", ModifiedAtTs: 10})},
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	var loaded ProjectCapture
+	if err := json.Unmarshal(b, &loaded); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if r := c.Diff(&loaded, HistoryOptions{}); len(r.Changes) != 0 || !loaded.CapturedAt.Equal(c.CapturedAt) {
+		t.Errorf(assertionTemplate, "loaded capture", loaded, c)
+	}
+}
+
+func TestSnapshotService_Capture(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	const restoredID = "restored.1"
+	deleted := false
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/snapshots", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			_, _ = fmt.Fprint(w, `{"snapshots": [{"snapshot_id": 5, "title": "release", "created_at_timestamp": 1000}]}`)
+		})
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/snapshots/5", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "POST")
+			_, _ = fmt.Fprint(w, `{"project_id": "`+restoredID+`", "name": "Copy"}`)
+		})
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/keys", restoredID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			_, _ = fmt.Fprint(w, `{"keys": [{"key_id": 1, "key_name": {"other": "title"}, "translations": [
+				{"language_iso": "en", "translation": "Title", "modified_by": 3, "modified_at_timestamp": 900}
+			]}]}`)
+		})
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s", restoredID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "DELETE")
+			deleted = true
+			_, _ = fmt.Fprint(w, `{"project_id": "`+restoredID+`", "project_deleted": true}`)
+		})
+
+	c, err := client.Snapshots().Capture(testProjectID, 5)
+	if err != nil {
+		t.Fatalf("Snapshots.Capture returned error: %v", err)
+	}
+	if !deleted {
+		t.Error("restored project was not deleted")
+	}
+	if c.ProjectID != testProjectID || c.SnapshotID != 5 || !c.CapturedAt.Equal(time.Unix(1000, 0)) {
+		t.Errorf(assertionTemplate, "Capture", c, "capture of snapshot 5")
+	}
+	if len(c.Keys) != 1 || c.Keys[0].Translations[0].ModifiedBy != 3 {
+		t.Errorf(assertionTemplate, "Capture keys", c.Keys, "1 key")
+	}
+
+	if _, err := client.Snapshots().Capture(testProjectID, 6); err == nil {
+		t.Error("Capture of an unknown snapshot returned no error")
+	}
+}
@@ -0,0 +1,317 @@
+package lokalise
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	defaultPseudoCustomName = "Pseudo"
+	defaultPseudoExpansion  = 0.3
+
+	pseudoPadding = '~'
+)
+
+// PseudoTranslator is an offline MachineTranslator producing pseudo-localized text:
+// latin letters are replaced with accented look-alikes, the text is expanded and wrapped in brackets,
+// i.e. "Save" becomes "[Šåṽé~~]" with an expansion of 0.3. It ignores the languages.
+//
+// Placeholders (printf, Ruby, i18next, Lokalise universal placeholders), HTML tags and entities, URLs
+// and the syntax of ICU messages are left untouched, only the texts of ICU plural and select cases are converted.
+type PseudoTranslator struct {
+	// Expansion is the length added to the text, as a fraction of its letters, i.e. 0.3 adds 30%.
+	// Translations are often longer than the English text, expanded text helps to catch truncation.
+	Expansion float64
+}
+
+var pseudoLetters = map[rune]rune{
+	'a': 'å', 'b': 'ƀ', 'c': 'ç', 'd': 'ð', 'e': 'é', 'f': 'ƒ', 'g': 'ĝ', 'h': 'ĥ', 'i': 'î', 'j': 'ĵ',
+	'k': 'ķ', 'l': 'ļ', 'm': 'ɱ', 'n': 'ñ', 'o': 'ö', 'p': 'þ', 'q': 'ǫ', 'r': 'ŕ', 's': 'š', 't': 'ţ',
+	'u': 'û', 'v': 'ṽ', 'w': 'ŵ', 'x': 'ẋ', 'y': 'ý', 'z': 'ž',
+	'A': 'Å', 'B': 'Ɓ', 'C': 'Ç', 'D': 'Ð', 'E': 'É', 'F': 'Ƒ', 'G': 'Ĝ', 'H': 'Ĥ', 'I': 'Î', 'J': 'Ĵ',
+	'K': 'Ķ', 'L': 'Ļ', 'M': 'Ṁ', 'N': 'Ñ', 'O': 'Ö', 'P': 'Þ', 'Q': 'Ǫ', 'R': 'Ŕ', 'S': 'Š', 'T': 'Ţ',
+	'U': 'Û', 'V': 'Ṽ', 'W': 'Ŵ', 'X': 'Ẋ', 'Y': 'Ý', 'Z': 'Ž',
+}
+
+// pseudoTokens match the parts of a text kept as is, braces are handled by the ICU parser.
+var pseudoTokens = regexp.MustCompile(`^(?:` +
+	`</?[a-zA-Z][^<>]*>` + // HTML tags
+	`|&(?:[a-zA-Z]+|#\d+|#x[0-9a-fA-F]+);` + // HTML entities
+	`|(?i:https?|ftp)://[^\s<>"']+` + // URLs
+	`|\[%[^\]]*\]` + // Lokalise universal placeholders
+	`|%\{[^}]+\}` + // Ruby
+	`|%(?:\d+\$)?[-+0#]*\d*(?:\.\d+)?(?:hh|h|ll|l|L|q|j|z|t)?[diouxXeEfFgGaAcsSp@%]` + // printf
+	`)`)
+
+var icuArgument = regexp.MustCompile(`^\{\s*[\w.-]+\s*(?:,\s*(\w+)\s*)?([,}])`)
+
+func (p PseudoTranslator) Translate(_ context.Context, _, _ string, texts []string) ([]string, error) {
+	r := make([]string, len(texts))
+	for i, t := range texts {
+		r[i] = p.PseudoLocalize(t)
+	}
+	return r, nil
+}
+
+// PseudoLocalize converts a single text, plural translation values are converted form by form.
+func (p PseudoTranslator) PseudoLocalize(s string) string {
+	if s == "" {
+		return ""
+	}
+	if isPluralValue(s) {
+		forms, _ := ParsePluralTranslation(s)
+		for form, text := range forms {
+			forms[form] = p.PseudoLocalize(text)
+		}
+		return forms.String()
+	}
+
+	var b strings.Builder
+	letters := pseudoConvert(&b, s, false)
+
+	padding := int(math.Ceil(float64(letters) * p.Expansion))
+	return "[" + b.String() + strings.Repeat(string(pseudoPadding), max(padding, 0)) + "]"
+}
+
+// pseudoConvert writes the pseudo-localized s to b and returns the number of converted letters.
+// In ICU plural cases, # is the number and is kept as is.
+func pseudoConvert(b *strings.Builder, s string, inPlural bool) (letters int) {
+	for i := 0; i < len(s); {
+		rest := s[i:]
+
+		if rest[0] == '{' {
+			if n, l := pseudoBraces(b, rest); n > 0 {
+				i += n
+				letters += l
+				continue
+			}
+		}
+		if loc := pseudoTokens.FindStringIndex(rest); loc != nil {
+			b.WriteString(rest[:loc[1]])
+			i += loc[1]
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(rest)
+		switch {
+		case inPlural && r == '#':
+			b.WriteRune(r)
+		case unicode.IsLetter(r):
+			b.WriteRune(pseudoLetter(r))
+			letters++
+		default:
+			b.WriteRune(r)
+		}
+		i += size
+	}
+	return letters
+}
+
+// pseudoBraces handles a placeholder or an ICU argument at the start of s. It returns the length
+// consumed and the number of converted letters, zero if s does not start with a well formed argument.
+func pseudoBraces(b *strings.Builder, s string) (n int, letters int) {
+	// i18next {{name}}
+	if strings.HasPrefix(s, "{{") {
+		if end := strings.Index(s, "}}"); end > 0 {
+			b.WriteString(s[:end+2])
+			return end + 2, 0
+		}
+		return 0, 0
+	}
+
+	m := icuArgument.FindStringSubmatchIndex(s)
+	if m == nil {
+		return 0, 0
+	}
+	end := closingBrace(s)
+	if end < 0 {
+		return 0, 0
+	}
+	argType := ""
+	if m[2] >= 0 {
+		argType = s[m[2]:m[3]]
+	}
+
+	switch argType {
+	case "plural", "selectordinal", "select":
+	default:
+		// {name}, {n, number} and {d, date, short} are kept as is
+		b.WriteString(s[:end+1])
+		return end + 1, 0
+	}
+
+	// cases: selector {message} ...
+	b.WriteString(s[:m[1]])
+	for i := m[1]; i < end; {
+		if s[i] != '{' {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+		caseEnd := closingBrace(s[i:])
+		if caseEnd < 0 {
+			return 0, 0
+		}
+		b.WriteByte('{')
+		letters += pseudoConvert(b, s[i+1:i+caseEnd], argType != "select")
+		b.WriteByte('}')
+		i += caseEnd + 1
+	}
+	b.WriteByte('}')
+	return end + 1, letters
+}
+
+// closingBrace returns the index of the brace closing the one s starts with, -1 if there is none.
+func closingBrace(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func pseudoLetter(r rune) rune {
+	if p, ok := pseudoLetters[r]; ok {
+		return p
+	}
+	return r
+}
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service request/response objects
+// _____________________________________________________________________________________________________________________
+
+type PseudoLocaleOptions struct {
+	// CustomISO is the code of the pseudo language, i.e. "en-XA". Required.
+	CustomISO string
+	// CustomName is the name of the pseudo language when it is created. Default: Pseudo
+	CustomName string
+	// SourceLang is the language converted, the project base language if empty.
+	// The pseudo language is created as a custom variant of it.
+	SourceLang string
+	// Translator converts the texts. Default: PseudoTranslator with an expansion of 0.3
+	Translator *PseudoTranslator
+	// Bulk configures the updates of the translations.
+	Bulk BulkTranslationUpdateOptions
+}
+
+type PseudoLocaleReport struct {
+	Language Language
+	// Created is set when the pseudo language did not exist yet.
+	Created bool
+	Results []TranslationUpdateResult
+	// Unchanged counts the translations already up to date.
+	Unchanged int
+}
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service methods
+// _____________________________________________________________________________________________________________________
+
+// GeneratePseudoLocale writes pseudo-localized source translations into a custom language of the project,
+// creating it if needed. Running it again regenerates incrementally: only the translations differing from
+// the pseudo-localized source are updated, translations of empty source translations are left as is.
+func (c *TranslationService) GeneratePseudoLocale(projectID string, opts PseudoLocaleOptions) (r PseudoLocaleReport, err error) {
+	if opts.CustomISO == "" {
+		return r, fmt.Errorf("lokalise: pseudo locale custom ISO is required")
+	}
+	if opts.CustomName == "" {
+		opts.CustomName = defaultPseudoCustomName
+	}
+	translator := PseudoTranslator{Expansion: defaultPseudoExpansion}
+	if opts.Translator != nil {
+		translator = *opts.Translator
+	}
+
+	if opts.SourceLang == "" {
+		ps := ProjectService{BaseService: c.BaseService}
+		project, err := ps.Retrieve(projectID)
+		if err != nil {
+			return r, err
+		}
+		opts.SourceLang = project.BaseLangISO
+	}
+
+	ls := LanguageService{c.BaseService}
+	langs, err := ls.listAll(projectID)
+	if err != nil {
+		return
+	}
+	var source Language
+	for _, l := range langs {
+		switch l.LangISO {
+		case opts.SourceLang:
+			source = l
+		case opts.CustomISO:
+			r.Language = l
+		}
+	}
+	if source.LangID == 0 {
+		return r, fmt.Errorf("lokalise: source language %s is not a project language", opts.SourceLang)
+	}
+
+	if r.Language.LangID == 0 {
+		created, err := ls.Create(projectID, []NewLanguage{{
+			LangISO:    opts.SourceLang,
+			CustomISO:  opts.CustomISO,
+			CustomName: opts.CustomName,
+		}})
+		if err != nil {
+			return r, err
+		}
+		if len(created.Languages) == 0 {
+			return r, fmt.Errorf("lokalise: pseudo language %s was not created", opts.CustomISO)
+		}
+		r.Language, r.Created = created.Languages[0], true
+	}
+
+	sourceTranslations, err := c.listAll(projectID, TranslationListOptions{FilterLangID: source.LangID})
+	if err != nil {
+		return
+	}
+	sourceTexts := make(map[int64]string, len(sourceTranslations))
+	for _, t := range sourceTranslations {
+		sourceTexts[t.KeyID] = t.Translation
+	}
+
+	targets, err := c.listAll(projectID, TranslationListOptions{FilterLangID: r.Language.LangID})
+	if err != nil {
+		return
+	}
+
+	var updates []TranslationUpdate
+	for _, t := range targets {
+		value := translator.PseudoLocalize(sourceTexts[t.KeyID])
+		if value == "" {
+			continue
+		}
+		if value == t.Translation || (isPluralValue(value) && equalPluralValues(value, t.Translation)) {
+			r.Unchanged++
+			continue
+		}
+		updates = append(updates, TranslationUpdate{TranslationID: t.TranslationID, Update: UpdateTranslation{Translation: value}})
+	}
+
+	r.Results, err = c.BulkUpdate(projectID, updates, opts.Bulk)
+	return r, err
+}
+
+// equalPluralValues compares plural translation values regardless of their formatting.
+func equalPluralValues(a, b string) bool {
+	pa, errA := ParsePluralTranslation(a)
+	pb, errB := ParsePluralTranslation(b)
+	return errA == nil && errB == nil && pa.String() == pb.String()
+}
@@ -0,0 +1,132 @@
+package lokalise
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestPseudoTranslator_PseudoLocalize(t *testing.T) {
+	p := PseudoTranslator{}
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"Save", "[Šåṽé]"},
+		{"Hello %s, you have %1$d files", "[Ĥéļļö %s, ýöû ĥåṽé %1$d ƒîļéš]"},
+		{"Hi {name} and {{user}}", "[Ĥî {name} åñð {{user}}]"},
+		{"Hi %{name} [%s:count]", "[Ĥî %{name} [%s:count]]"},
+		{`<a href="https://example.com">Link</a>&nbsp;`, `[<a href="https://example.com">Ļîñķ</a>&nbsp;]`},
+		{"See https://example.com/help", "[Šéé https://example.com/help]"},
+		{"{n, number} items", "[{n, number} îţéɱš]"},
+		{
+			"{count, plural, one {# file} other {# files in {dir}}}",
+			"[{count, plural, one {# ƒîļé} other {# ƒîļéš îñ {dir}}}]",
+		},
+		{
+			"{gender, select, female {She #1} other {They}}",
+			"[{gender, select, female {Šĥé #1} other {Ţĥéý}}]",
+		},
+		{"Unbalanced {brace", "[Ûñƀåļåñçéð {ƀŕåçé]"},
+		{`{"one":"1 file","other":"files"}`, `{"one":"[1 ƒîļé]","other":"[ƒîļéš]"}`},
+	}
+	for _, tt := range tests {
+		if got := p.PseudoLocalize(tt.in); got != tt.want {
+			t.Errorf(assertionTemplate, "PseudoLocalize("+tt.in+")", got, tt.want)
+		}
+	}
+
+	expanded := PseudoTranslator{Expansion: 0.5}
+	if got, want := expanded.PseudoLocalize("Save {n}"), "[Šåṽé {n}~~]"; got != want {
+		t.Errorf(assertionTemplate, "PseudoLocalize with expansion", got, want)
+	}
+}
+
+func TestTranslationService_GeneratePseudoLocale(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/languages", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.Method {
+			case http.MethodGet:
+				_, _ = fmt.Fprint(w, `{"languages": [{"lang_id": 1, "lang_iso": "en"}]}`)
+			case http.MethodPost:
+				testBody(t, r, `{"languages":[{"lang_iso":"en","custom_iso":"en-XA","custom_name":"Pseudo"}]}`)
+				_, _ = fmt.Fprint(w, `{"languages": [{"lang_id": 9, "lang_iso": "en-XA"}]}`)
+			default:
+				t.Errorf("unexpected method %s", r.Method)
+			}
+		})
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/translations", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			switch r.URL.Query().Get("filter_lang_id") {
+			case "1":
+				_, _ = fmt.Fprint(w, `{"translations": [
+					{"translation_id": 11, "key_id": 1, "translation": "Save"},
+					{"translation_id": 12, "key_id": 2, "translation": "Open"},
+					{"translation_id": 13, "key_id": 3, "translation": ""}
+				]}`)
+			case "9":
+				_, _ = fmt.Fprint(w, `{"translations": [
+					{"translation_id": 91, "key_id": 1, "translation": ""},
+					{"translation_id": 92, "key_id": 2, "translation": "[Öþéñ~~]"},
+					{"translation_id": 93, "key_id": 3, "translation": ""}
+				]}`)
+			default:
+				t.Errorf("unexpected filter_lang_id %q", r.URL.Query().Get("filter_lang_id"))
+			}
+		})
+
+	var (
+		mu     sync.Mutex
+		bodies = make(map[string]string)
+	)
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/translations/", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "PUT")
+			id := strings.TrimPrefix(r.URL.Path, fmt.Sprintf("/projects/%s/translations/", testProjectID))
+			b, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			bodies[id] = string(b)
+			mu.Unlock()
+			_, _ = fmt.Fprintf(w, `{"translation": {"translation_id": %s}}`, id)
+		})
+
+	r, err := client.Translations().GeneratePseudoLocale(testProjectID, PseudoLocaleOptions{
+		CustomISO:  "en-XA",
+		SourceLang: "en",
+	})
+	if err != nil {
+		t.Fatalf("Translations.GeneratePseudoLocale returned error: %v", err)
+	}
+	if !r.Created || r.Language.LangID != 9 || r.Unchanged != 1 || len(r.Results) != 1 {
+		t.Errorf(assertionTemplate, "GeneratePseudoLocale report", r, "created, 1 unchanged, 1 update")
+	}
+
+	want := map[string]string{"91": `{"translation":"[Šåṽé~~]"}`}
+	if !reflect.DeepEqual(bodies, want) {
+		t.Errorf(assertionTemplate, "update bodies", bodies, want)
+	}
+}
+
+func TestTranslationService_GeneratePseudoLocale_RequiresCustomISO(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	if _, err := client.Translations().GeneratePseudoLocale(testProjectID, PseudoLocaleOptions{}); err == nil {
+		t.Error("GeneratePseudoLocale without custom ISO returned no error")
+	}
+}
@@ -0,0 +1,368 @@
+/*
+Package qa runs the Lokalise QA checks locally, before translations are pushed to a project.
+
+Issues are named after the QAIssues fields of project statistics, so that local findings
+can be compared with the counts reported by the API.
+
+Usage:
+
+	findings := qa.Check(base, target, qa.DefaultRules())
+
+	// rules per project
+	checker := qa.Checker{
+		Default:  qa.DefaultRules(),
+		Projects: map[string]qa.Rules{"{PROJECT_ID}": {Issues: []qa.Issue{qa.InconsistentPlaceholders}}},
+	}
+	findings = checker.Check("{PROJECT_ID}", base, target)
+*/
+package qa
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/lokalise/go-lokalise-api/v5"
+)
+
+// Issue is a QA check, its value is the JSON name of the matching QAIssues field.
+type Issue string
+
+const (
+	InconsistentPlaceholders      Issue = "inconsistent_placeholders"
+	InconsistentHtml              Issue = "inconsistent_html"
+	DifferentNumberOfUrls         Issue = "different_number_of_urls"
+	DifferentUrls                 Issue = "different_urls"
+	LeadingWhitespace             Issue = "leading_whitespace"
+	TrailingWhitespace            Issue = "trailing_whitespace"
+	DifferentNumberOfEmailAddress Issue = "different_number_of_email_address"
+	DifferentEmailAddress         Issue = "different_email_address"
+	DifferentBrackets             Issue = "different_brackets"
+	DifferentNumbers              Issue = "different_numbers"
+	DoubleSpace                   Issue = "double_space"
+)
+
+// AllIssues lists every check in the order they are run.
+var AllIssues = []Issue{
+	InconsistentPlaceholders,
+	InconsistentHtml,
+	DifferentNumberOfUrls,
+	DifferentUrls,
+	DifferentNumberOfEmailAddress,
+	DifferentEmailAddress,
+	DifferentNumbers,
+	DifferentBrackets,
+	LeadingWhitespace,
+	TrailingWhitespace,
+	DoubleSpace,
+}
+
+// DefaultPlaceholders match printf, iOS, ICU, i18next, Ruby and Lokalise universal placeholders.
+var DefaultPlaceholders = []*regexp.Regexp{
+	regexp.MustCompile(`\[%[^\]%]*%\]`),
+	regexp.MustCompile(`%(?:\d+\$)?[-+0#]*\d*(?:\.\d+)?(?:hh|h|ll|l|L|q|j|z|t)?[diouxXeEfFgGaAcsSp@%]`),
+	regexp.MustCompile(`%\{[^}]+\}`),
+	regexp.MustCompile(`\{\{[^}]+\}\}`),
+	regexp.MustCompile(`\{[\w.-]+\}`),
+}
+
+var (
+	htmlTagRegexp = regexp.MustCompile(`</?([a-zA-Z][\w-]*)[^<>]*?/?>`)
+	urlRegexp     = regexp.MustCompile(`(?i)\b(?:https?|ftp)://[^\s<>"']+`)
+	emailRegexp   = regexp.MustCompile(`[\w.+-]+@[\w-]+(?:\.[\w-]+)+`)
+	numberRegexp  = regexp.MustCompile(`\d+(?:[.,\x{00A0}\x{202F}]\d+)*`)
+)
+
+// Rules configures the checks run on a translation.
+type Rules struct {
+	// Issues are the checks to run, all of them if empty.
+	Issues []Issue
+	// Placeholders are the patterns of placeholders, DefaultPlaceholders if empty.
+	// Placeholders are excluded from the number and bracket checks.
+	Placeholders []*regexp.Regexp
+}
+
+// DefaultRules runs every check with the default placeholders.
+func DefaultRules() Rules {
+	return Rules{Issues: AllIssues, Placeholders: DefaultPlaceholders}
+}
+
+// Finding is an issue found in a target translation.
+type Finding struct {
+	Issue Issue
+	// PluralForm is set when the issue was found in a single form of a plural translation.
+	PluralForm string
+	Message    string
+}
+
+// Checker holds the rules of several projects.
+type Checker struct {
+	// Default is used for projects without specific rules.
+	Default  Rules
+	Projects map[string]Rules
+}
+
+// Rules returns the rules of the project.
+func (c Checker) Rules(projectID string) Rules {
+	if r, ok := c.Projects[projectID]; ok {
+		return r
+	}
+	return c.Default
+}
+
+// Check runs the rules of the project on a target translation against its base translation.
+func (c Checker) Check(projectID string, base, target lokalise.Translation) []Finding {
+	return Check(base, target, c.Rules(projectID))
+}
+
+// Check runs the rules on a target translation against its base translation.
+// Plural translations are checked form by form; target forms missing in the base
+// translation are compared with its "other" form. Empty translations are not checked.
+func Check(base, target lokalise.Translation, rules Rules) []Finding {
+	if target.Translation == "" || base.Translation == "" {
+		return nil
+	}
+	if !target.IsPluralValue() {
+		return CheckText(base.Translation, target.Translation, rules)
+	}
+
+	targetForms, _ := target.Plural()
+	baseForms, _ := base.Plural()
+	var findings []Finding
+	for _, form := range targetForms.Forms() {
+		b, ok := baseForms[form]
+		if !ok {
+			b = baseForms["other"]
+		}
+		if b == "" || targetForms[form] == "" {
+			continue
+		}
+		for _, f := range CheckText(b, targetForms[form], rules) {
+			f.PluralForm = form
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// CheckText runs the rules on plain strings.
+func CheckText(base, target string, rules Rules) []Finding {
+	issues := rules.Issues
+	if len(issues) == 0 {
+		issues = AllIssues
+	}
+	placeholders := rules.Placeholders
+	if len(placeholders) == 0 {
+		placeholders = DefaultPlaceholders
+	}
+
+	enabled := make(map[Issue]bool, len(issues))
+	for _, i := range issues {
+		enabled[i] = true
+	}
+
+	var findings []Finding
+	add := func(issue Issue, format string, args ...interface{}) {
+		findings = append(findings, Finding{Issue: issue, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if enabled[InconsistentPlaceholders] {
+		b, t := matchAll(placeholders, base), matchAll(placeholders, target)
+		if !sameMultiset(b, t) {
+			add(InconsistentPlaceholders, "placeholders %v do not match %v", t, b)
+		}
+	}
+
+	if enabled[InconsistentHtml] {
+		b, t := htmlTags(base), htmlTags(target)
+		if !sameMultiset(b, t) {
+			add(InconsistentHtml, "HTML tags %v do not match %v", t, b)
+		}
+	}
+
+	bURLs, tURLs := urls(base), urls(target)
+	switch {
+	case len(bURLs) != len(tURLs):
+		if enabled[DifferentNumberOfUrls] {
+			add(DifferentNumberOfUrls, "%d URLs instead of %d", len(tURLs), len(bURLs))
+		}
+	case !sameMultiset(bURLs, tURLs):
+		if enabled[DifferentUrls] {
+			add(DifferentUrls, "URLs %v do not match %v", tURLs, bURLs)
+		}
+	}
+
+	bEmails, tEmails := emailRegexp.FindAllString(base, -1), emailRegexp.FindAllString(target, -1)
+	switch {
+	case len(bEmails) != len(tEmails):
+		if enabled[DifferentNumberOfEmailAddress] {
+			add(DifferentNumberOfEmailAddress, "%d email addresses instead of %d", len(tEmails), len(bEmails))
+		}
+	case !sameMultiset(bEmails, tEmails):
+		if enabled[DifferentEmailAddress] {
+			add(DifferentEmailAddress, "email addresses %v do not match %v", tEmails, bEmails)
+		}
+	}
+
+	// numbers and brackets are compared on the prose only
+	bText, tText := stripTokens(base, placeholders), stripTokens(target, placeholders)
+
+	if enabled[DifferentNumbers] {
+		b, t := numbers(bText), numbers(tText)
+		if !sameMultiset(b, t) {
+			add(DifferentNumbers, "numbers %v do not match %v", t, b)
+		}
+	}
+
+	if enabled[DifferentBrackets] {
+		b, t := brackets(bText), brackets(tText)
+		if b != t {
+			add(DifferentBrackets, "brackets %q do not match %q", t, b)
+		}
+	}
+
+	if enabled[LeadingWhitespace] && hasLeadingSpace(base) != hasLeadingSpace(target) {
+		add(LeadingWhitespace, "leading whitespace differs from the base translation")
+	}
+
+	if enabled[TrailingWhitespace] && hasTrailingSpace(base) != hasTrailingSpace(target) {
+		add(TrailingWhitespace, "trailing whitespace differs from the base translation")
+	}
+
+	if enabled[DoubleSpace] && strings.Contains(target, "  ") && !strings.Contains(base, "  ") {
+		add(DoubleSpace, "double space")
+	}
+
+	return findings
+}
+
+// Summarize counts the findings into the QAIssues structure of project statistics.
+func Summarize(findings []Finding) lokalise.QAIssues {
+	var s lokalise.QAIssues
+	for _, f := range findings {
+		switch f.Issue {
+		case InconsistentPlaceholders:
+			s.InconsistentPlaceholders++
+		case InconsistentHtml:
+			s.InconsistentHtml++
+		case DifferentNumberOfUrls:
+			s.DifferentNumberOfUrls++
+		case DifferentUrls:
+			s.DifferentUrls++
+		case LeadingWhitespace:
+			s.LeadingWhitespace++
+		case TrailingWhitespace:
+			s.TrailingWhitespace++
+		case DifferentNumberOfEmailAddress:
+			s.DifferentNumberOfEmailAddress++
+		case DifferentEmailAddress:
+			s.DifferentEmailAddress++
+		case DifferentBrackets:
+			s.DifferentBrackets++
+		case DifferentNumbers:
+			s.DifferentNumbers++
+		case DoubleSpace:
+			s.DoubleSpace++
+		}
+	}
+	return s
+}
+
+func matchAll(patterns []*regexp.Regexp, s string) []string {
+	var found []string
+	for _, p := range patterns {
+		for _, loc := range p.FindAllStringIndex(s, -1) {
+			found = append(found, s[loc[0]:loc[1]])
+			s = s[:loc[0]] + strings.Repeat("\x00", loc[1]-loc[0]) + s[loc[1]:]
+		}
+	}
+	sort.Strings(found)
+	return found
+}
+
+// urls returns the URLs of s without the trailing punctuation of the sentence.
+func urls(s string) []string {
+	found := urlRegexp.FindAllString(s, -1)
+	for i, u := range found {
+		found[i] = strings.TrimRight(u, ".,;:!?)")
+	}
+	return found
+}
+
+// htmlTags returns the opening and closing tag names, i.e. "b" and "/b".
+func htmlTags(s string) []string {
+	var tags []string
+	for _, m := range htmlTagRegexp.FindAllStringSubmatch(s, -1) {
+		name := strings.ToLower(m[1])
+		if strings.HasPrefix(m[0], "</") {
+			name = "/" + name
+		}
+		tags = append(tags, name)
+	}
+	return tags
+}
+
+// stripTokens removes placeholders, HTML tags, URLs and email addresses.
+func stripTokens(s string, placeholders []*regexp.Regexp) string {
+	for _, p := range placeholders {
+		s = p.ReplaceAllString(s, " ")
+	}
+	for _, p := range []*regexp.Regexp{htmlTagRegexp, urlRegexp, emailRegexp} {
+		s = p.ReplaceAllString(s, " ")
+	}
+	return s
+}
+
+// numbers returns the digits of every number, ignoring locale specific separators.
+func numbers(s string) []string {
+	var found []string
+	for _, n := range numberRegexp.FindAllString(s, -1) {
+		found = append(found, strings.Map(func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, n))
+	}
+	return found
+}
+
+// brackets returns the sorted brackets of s.
+func brackets(s string) string {
+	var b []rune
+	for _, r := range s {
+		switch r {
+		case '(', ')', '[', ']', '{', '}':
+			b = append(b, r)
+		}
+	}
+	sort.Slice(b, func(i, j int) bool { return b[i] < b[j] })
+	return string(b)
+}
+
+func hasLeadingSpace(s string) bool {
+	return s != strings.TrimLeftFunc(s, unicode.IsSpace)
+}
+
+func hasTrailingSpace(s string) bool {
+	return s != strings.TrimRightFunc(s, unicode.IsSpace)
+}
+
+func sameMultiset(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	count := make(map[string]int, len(a))
+	for _, s := range a {
+		count[s]++
+	}
+	for _, s := range b {
+		if count[s] == 0 {
+			return false
+		}
+		count[s]--
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package qa
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/lokalise/go-lokalise-api/v5"
+)
+
+func issues(findings []Finding) []Issue {
+	var found []Issue
+	for _, f := range findings {
+		found = append(found, f.Issue)
+	}
+	return found
+}
+
+func TestCheckText(t *testing.T) {
+	tests := []struct {
+		base, target string
+		want         []Issue
+	}{
+		{"Hello %s, you have %d messages", "Hallo %s, du hast %d Nachrichten", nil},
+		{"Hello {name}", "Hallo {nom}", []Issue{InconsistentPlaceholders}},
+		{"Hello [%s:name%]", "Hallo", []Issue{InconsistentPlaceholders}},
+		{"100% sure", "100 % sicher", nil},
+		{"<b>Bold</b> text", "<b>Fett</b> Text", nil},
+		{"<b>Bold</b> text", "<i>Fett</i> Text", []Issue{InconsistentHtml}},
+		{"See https://lokalise.com.", "Siehe https://lokalise.com", nil},
+		{"See https://lokalise.com", "Siehe https://lokalise.de", []Issue{DifferentUrls}},
+		{"See https://lokalise.com", "Siehe", []Issue{DifferentNumberOfUrls}},
+		{"Mail support@lokalise.com", "Mail help@lokalise.com", []Issue{DifferentEmailAddress}},
+		{"Mail support@lokalise.com", "Mail", []Issue{DifferentNumberOfEmailAddress}},
+		{"1,000.5 items", "1.000,5 Artikel", nil},
+		{"3 items", "4 Artikel", []Issue{DifferentNumbers}},
+		{"Save (now)", "Speichern jetzt)", []Issue{DifferentBrackets}},
+		{"Save", " Speichern", []Issue{LeadingWhitespace}},
+		{"Save ", "Speichern", []Issue{TrailingWhitespace}},
+		{"Save now", "Jetzt  speichern", []Issue{DoubleSpace}},
+	}
+
+	for _, tt := range tests {
+		if got := issues(CheckText(tt.base, tt.target, DefaultRules())); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("CheckText(%q, %q) found %v, want %v", tt.base, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestCheck_Plural(t *testing.T) {
+	base := lokalise.Translation{Translation: `{"one":"%d file","other":"%d files"}`}
+	target := lokalise.Translation{Translation: `{"one":"%d fails","zero":"%d failu","other":"failu"}`}
+
+	want := []Finding{
+		{Issue: InconsistentPlaceholders, PluralForm: "other", Message: "placeholders [] do not match [%d]"},
+	}
+	if got := Check(base, target, DefaultRules()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Check returned %+v, want %+v", got, want)
+	}
+}
+
+func TestChecker_Check(t *testing.T) {
+	checker := Checker{
+		Default: DefaultRules(),
+		Projects: map[string]Rules{
+			"custom": {
+				Issues:       []Issue{InconsistentPlaceholders},
+				Placeholders: []*regexp.Regexp{regexp.MustCompile(`:\w+`)},
+			},
+		},
+	}
+	base := lokalise.Translation{Translation: "Hello :name "}
+	target := lokalise.Translation{Translation: "Hallo :nom"}
+
+	if got := issues(checker.Check("custom", base, target)); !reflect.DeepEqual(got, []Issue{InconsistentPlaceholders}) {
+		t.Errorf("Checker.Check found %v for the custom project", got)
+	}
+	if got := issues(checker.Check("other", base, target)); !reflect.DeepEqual(got, []Issue{TrailingWhitespace}) {
+		t.Errorf("Checker.Check found %v for the default rules", got)
+	}
+
+	s := Summarize(checker.Check("other", base, target))
+	if s != (lokalise.QAIssues{TrailingWhitespace: 1}) {
+		t.Errorf("Summarize returned %+v", s)
+	}
+}
@@ -0,0 +1,45 @@
+package lokalise
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter spaces out requests evenly so that no more than the configured number of requests per second is sent.
+// It is shared by all the services of a client.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(requestsPerSecond int) *rateLimiter {
+	return &rateLimiter{interval: time.Second / time.Duration(requestsPerSecond)}
+}
+
+// wait blocks until the next request may be sent or the context is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	at := l.next
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	d := time.Until(at)
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,60 @@
+package lokalise
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	l := newRateLimiter(20)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.wait(context.Background()); err != nil {
+			t.Errorf("rateLimiter.wait returned error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("3 requests at 20 rps took %s, want at least 100ms", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = l.wait(ctx)
+	if err := l.wait(ctx); err != context.Canceled {
+		t.Errorf("rateLimiter.wait returned %v, want context.Canceled", err)
+	}
+}
+
+func TestWithRetryOnRateLimit(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	attempts := 0
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/languages", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.Header().Set("Content-Type", "application/json")
+			if attempts == 1 {
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = fmt.Fprint(w, `{"error": {"code": 429, "message": "Too many requests"}}`)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"languages": []}`)
+		})
+
+	if _, err := client.Languages().ListProject(testProjectID); err == nil || attempts != 1 {
+		t.Errorf("Languages.ListProject: err = %v after %d attempts, want a 429 error after 1 attempt by default", err, attempts)
+	}
+
+	_ = WithRetryTimeout(time.Millisecond)(client)
+	_ = WithRetryOnRateLimit(true)(client)
+	attempts = 0
+	if _, err := client.Languages().ListProject(testProjectID); err != nil || attempts != 2 {
+		t.Errorf("Languages.ListProject: err = %v after %d attempts, want success after 2 attempts", err, attempts)
+	}
+}
@@ -0,0 +1,59 @@
+package lokalise
+
+import (
+	"strings"
+	"unicode"
+)
+
+// normalizeText lower cases s and collapses all whitespace runs into single spaces.
+func normalizeText(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), unicode.IsSpace), " ")
+}
+
+// similarity returns a score between 0 and 1 based on the Levenshtein distance of a and b,
+// 1 meaning the strings are equal.
+func similarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// maxSimilarity is an upper bound of similarity computed from the string lengths only,
+// it allows to skip the distance computation for obviously different strings.
+func maxSimilarity(a, b string) float64 {
+	la, lb := len([]rune(a)), len([]rune(b))
+	longest := max(la, lb)
+	if longest == 0 {
+		return 1
+	}
+	return float64(min(la, lb)) / float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,201 @@
+package lokalise
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// UploadProgress is called while the content of a streamed upload is sent, with the number of bytes
+// read so far and the content size, -1 if unknown. It is called again from zero when the request is retried.
+type UploadProgress func(read, total int64)
+
+// streamDataToken marks the place of the streamed content in the JSON body.
+const streamDataToken = "\x00lokalise-stream\x00"
+
+// postStream posts body as JSON with the base64 encoded content in place of streamDataToken. The body is
+// written to the connection as it is encoded, so that its size does not matter. Resty is bypassed as it
+// reads io.Reader bodies into memory to be able to retry them: retries only happen here when the content
+// is an io.Seeker which can be rewound. The rate limit and debug log of the client apply as for the other requests.
+func (c *restClient) postStream(ctx context.Context, path string, res, body interface{}, content io.Reader, progress UploadProgress) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	token, _ := json.Marshal(streamDataToken)
+	token = token[1 : len(token)-1]
+	i := bytes.Index(data, token)
+	if i < 0 {
+		return errors.New("lokalise: no place for the streamed content")
+	}
+	prefix, suffix := data[:i], data[i+len(token):]
+
+	total := contentSize(content)
+	seeker, canRetry := content.(io.Seeker)
+	var start int64
+	retries := 0
+	if canRetry {
+		if start, err = seeker.Seek(0, io.SeekCurrent); err == nil {
+			retries = c.Client.RetryCount
+		}
+	}
+	wait := c.Client.RetryWaitTime
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+				return err
+			}
+		}
+		if c.limiter != nil {
+			if err := c.limiter.wait(ctx); err != nil {
+				return err
+			}
+		}
+		status, respBody, err := c.sendStream(ctx, path, prefix, suffix, content, total, progress)
+		retry := err != nil || c.retryStatus(status)
+		if !retry || attempt >= retries || ctx.Err() != nil {
+			if err != nil {
+				return err
+			}
+			return streamResult(status, respBody, res)
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+		wait = min(2*wait, max(c.Client.RetryMaxWaitTime, wait))
+	}
+}
+
+func (c *restClient) sendStream(ctx context.Context, path string, prefix, suffix []byte, content io.Reader, total int64, progress UploadProgress) (int, []byte, error) {
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		enc := base64.NewEncoder(base64.StdEncoding, pw)
+		_, err := io.Copy(enc, &progressReader{r: content, total: total, progress: progress})
+		if err == nil {
+			err = enc.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	// the content must not be read anymore once returned, as it is rewound for retries
+	defer func() {
+		pr.Close()
+		<-done
+	}()
+
+	url := strings.TrimSuffix(c.Client.BaseURL, "/") + "/" + strings.TrimPrefix(path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, io.MultiReader(bytes.NewReader(prefix), pr, bytes.NewReader(suffix)))
+	if err != nil {
+		return 0, nil, err
+	}
+	for k, v := range c.Client.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if total >= 0 {
+		req.ContentLength = int64(len(prefix)) + int64(base64.StdEncoding.EncodedLen(int(total))) + int64(len(suffix))
+	}
+
+	if c.Client.Debug {
+		streamLogger.Printf("DEBUG RESTY \n~~~ REQUEST ~~~\n%s  %s\nHEADERS:\n%s\nBODY   :\n***** STREAMED, %d BYTES OF CONTENT *****\n",
+			req.Method, req.URL.RequestURI(), debugHeaders(req.Header), total)
+	}
+	resp, err := c.Client.GetClient().Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if c.Client.Debug {
+		streamLogger.Printf("DEBUG RESTY \n~~~ RESPONSE ~~~\nSTATUS : %s\nHEADERS:\n%s\nBODY   :\n%s\n",
+			resp.Status, debugHeaders(resp.Header), respBody)
+	}
+	return resp.StatusCode, respBody, err
+}
+
+// streamLogger logs streamed requests like the default logger of resty.
+var streamLogger = log.New(os.Stderr, "", log.Ldate|log.Lmicroseconds)
+
+func debugHeaders(h http.Header) string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&sb, "\t%s: %s\n", k, strings.Join(h[k], ", "))
+	}
+	return sb.String()
+}
+
+// streamResult decodes the response of a streamed request like apiError does for the other requests.
+func streamResult(status int, body []byte, res interface{}) error {
+	if status >= http.StatusBadRequest {
+		var e errorResponse
+		if err := json.Unmarshal(body, &e); err != nil || e.Error.Code == 0 {
+			return Error{Code: status, Message: http.StatusText(status)}
+		}
+		return e.Error
+	}
+	if res == nil || len(body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, res); err != nil {
+		return fmt.Errorf("lokalise: decoding response: %w", err)
+	}
+	return nil
+}
+
+// contentSize returns the size of the content left to read, or -1 if unknown.
+func contentSize(r io.Reader) int64 {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return int64(v.Len())
+	case *os.File:
+		fi, err := v.Stat()
+		if err != nil || !fi.Mode().IsRegular() {
+			return -1
+		}
+		pos, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		return fi.Size() - pos
+	}
+	return -1
+}
+
+type progressReader struct {
+	r        io.Reader
+	read     int64
+	total    int64
+	progress UploadProgress
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	if p.progress != nil && n > 0 {
+		p.progress(p.read, p.total)
+	}
+	return n, err
+}
@@ -0,0 +1,162 @@
+package lokalise
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileService_UploadReader(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	content := strings.Repeat(`{"key": "value"}`, 10000)
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/files/upload", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "POST")
+			testHeader(t, r, apiTokenHeader, testApiToken)
+			testHeader(t, r, "Content-Type", "application/json")
+
+			var upload FileUpload
+			if err := json.NewDecoder(r.Body).Decode(&upload); err != nil {
+				t.Fatalf("decoding body: %v", err)
+			}
+			if r.ContentLength <= 0 {
+				t.Errorf("ContentLength = %d, want the body size", r.ContentLength)
+			}
+			data, _ := base64.StdEncoding.DecodeString(upload.Data)
+			if string(data) != content {
+				t.Errorf("uploaded content of %d bytes, want %d bytes", len(data), len(content))
+			}
+			if upload.Filename != "en.json" || upload.LangISO != "en" || !upload.Queue || *upload.CustomTranslationStatusSkippedKeys {
+				t.Errorf("upload options = %+v", upload)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"project_id": "%s", "process": {"process_id": "abc", "status": "queued"}}`, testProjectID)
+		})
+
+	var read, total int64
+	r, err := client.Files().UploadReader(testProjectID, strings.NewReader(content), FileUpload{
+		Data:     "ignored",
+		Filename: "en.json",
+		LangISO:  "en",
+	}, func(n, size int64) { read, total = n, size })
+	if err != nil {
+		t.Fatalf("Files.UploadReader returned error: %v", err)
+	}
+	if r.ProjectID != testProjectID || r.Process.ID != "abc" {
+		t.Errorf("Files.UploadReader returned %+v", r)
+	}
+	if read != int64(len(content)) || total != int64(len(content)) {
+		t.Errorf("progress = %d/%d, want %d/%d", read, total, len(content), len(content))
+	}
+}
+
+func TestFileService_UploadReader_Retry(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	attempts := 0
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/files/upload", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			var upload FileUpload
+			_ = json.NewDecoder(r.Body).Decode(&upload)
+			if upload.Data != base64.StdEncoding.EncodeToString([]byte("content")) {
+				t.Errorf("attempt %d: data = %q", attempts, upload.Data)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			if attempts == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = fmt.Fprint(w, `{"error": {"code": 503, "message": "Unavailable"}}`)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"process": {"process_id": "abc"}}`)
+		})
+
+	_, err := client.Files().UploadReader(testProjectID, bytes.NewReader([]byte("content")), FileUpload{LangISO: "en"}, nil)
+	if err != nil || attempts != 2 {
+		t.Errorf("Files.UploadReader of a seekable reader: err = %v after %d attempts, want success after 2", err, attempts)
+	}
+
+	// readers which cannot be rewound are sent once
+	attempts = 0
+	_, err = client.Files().UploadReader(testProjectID, io.MultiReader(strings.NewReader("content")), FileUpload{LangISO: "en"}, nil)
+	if want := (Error{Code: 503, Message: "Unavailable"}); err != want || attempts != 1 {
+		t.Errorf("Files.UploadReader of a stream: err = %v after %d attempts, want %v after 1", err, attempts, want)
+	}
+}
+
+func TestFileService_UploadReader_Middleware(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/files/upload", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.Copy(io.Discard, r.Body)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"process": {"process_id": "abc"}}`)
+		})
+
+	if err := WithRateLimit(20)(client); err != nil {
+		t.Fatal(err)
+	}
+	_ = WithDebug(true)(client)
+	var debug bytes.Buffer
+	defer func(l *log.Logger) { streamLogger = l }(streamLogger)
+	streamLogger = log.New(&debug, "", 0)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.Files().UploadReader(testProjectID, strings.NewReader("content"), FileUpload{LangISO: "en"}, nil); err != nil {
+			t.Fatalf("Files.UploadReader returned error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("3 uploads at 20 rps took %s, want at least 100ms", elapsed)
+	}
+	if got := strings.Count(debug.String(), "~~~ REQUEST ~~~"); got != 3 {
+		t.Errorf("debug log has %d requests, want 3:\n%s", got, debug.String())
+	}
+}
+
+func TestScreenshotService_CreateReader(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/screenshots", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "POST")
+			testBody(t, r, `{"screenshots":[{"data":"data:image/png;base64,iVBORw==","title":"Home","key_ids":[1]}]}`)
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"screenshots": [{"screenshot_id": 5, "title": "Home"}]}`)
+		})
+
+	r, err := client.Screenshots().CreateReader(testProjectID, strings.NewReader("\x89PNG"), "image/png",
+		NewScreenshot{Title: "Home", KeyIDs: []int64{1}}, nil)
+	if err != nil {
+		t.Fatalf("Screenshots.CreateReader returned error: %v", err)
+	}
+	if len(r.Screenshots) != 1 || r.Screenshots[0].ScreenshotID != 5 {
+		t.Errorf("Screenshots.CreateReader returned %+v", r)
+	}
+
+	if _, err := client.Screenshots().CreateReader(testProjectID, strings.NewReader(""), "", NewScreenshot{}, nil); err == nil {
+		t.Error("Screenshots.CreateReader without MIME type returned no error")
+	}
+}
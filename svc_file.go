@@ -2,12 +2,17 @@ package lokalise
 
 import (
 	"fmt"
+	"io"
+	"path"
+
 	"github.com/go-resty/resty/v2"
 	"github.com/google/go-querystring/query"
 )
 
 const (
 	pathFiles = "files"
+
+	maxFilesPageLimit = 5000
 )
 
 type FileService struct {
@@ -24,6 +29,8 @@ type File struct {
 	FileId   int64  `json:"file_id"`
 	Filename string `json:"filename"`
 	KeyCount int64  `json:"key_count"`
+	// KeyCounts are the numbers of keys assigned to the file per platform, see FileListOptions.PlatformKeyCounts.
+	KeyCounts map[string]int64 `json:"-"`
 }
 
 type FileUpload struct {
@@ -123,28 +130,32 @@ type FileAsyncDownloadResponse struct {
 // Service methods
 // _____________________________________________________________________________________________________________________
 
+// List lists the files of a page, or of every page whose name matches FileListOptions.FilenameGlob.
 func (c *FileService) List(projectID string) (r FilesResponse, err error) {
-	resp, err := c.getWithOptions(c.Ctx(), fmt.Sprintf("%s/%s/%s", pathProjects, projectID, pathFiles), &r, c.ListOpts())
-
+	opts := c.ListOpts()
+	if opts.FilenameGlob != "" {
+		r, err = c.listGlob(projectID, opts)
+	} else {
+		var resp *resty.Response
+		resp, err = c.getWithOptions(c.Ctx(), fmt.Sprintf("%s/%s/%s", pathProjects, projectID, pathFiles), &r, opts)
+		if err != nil {
+			return
+		}
+		applyPaged(resp, &r.Paged)
+		err = apiError(resp)
+	}
 	if err != nil {
 		return
 	}
-	applyPaged(resp, &r.Paged)
-	return r, apiError(resp)
-}
 
-func (c *FileService) Upload(projectID string, file FileUpload) (r FileUploadResponse, err error) {
-	if file.CustomTranslationStatusSkippedKeys == nil {
-		file.CustomTranslationStatusSkippedKeys = Bool(false)
-	}
-	if file.CustomTranslationStatusUpdatedKeys == nil {
-		file.CustomTranslationStatusUpdatedKeys = Bool(true)
-	}
-	if file.CustomTranslationStatusInsertedKeys == nil {
-		file.CustomTranslationStatusInsertedKeys = Bool(true)
+	if opts.PlatformKeyCounts && len(r.Files) > 0 {
+		err = c.countPlatformKeys(projectID, r.Files)
 	}
+	return
+}
 
-	file.Queue = true
+func (c *FileService) Upload(projectID string, file FileUpload) (r FileUploadResponse, err error) {
+	file.setDefaults()
 
 	resp, err := c.post(c.Ctx(), fmt.Sprintf("%s/%s/%s/%s", pathProjects, projectID, pathFiles, "upload"), &r, file)
 
@@ -154,6 +165,17 @@ func (c *FileService) Upload(projectID string, file FileUpload) (r FileUploadRes
 	return r, apiError(resp)
 }
 
+// UploadReader uploads the content read from r, streaming it base64 encoded into the request body
+// instead of holding it in memory, see Upload. file.Data is ignored.
+// The request is only retried if content is an io.Seeker.
+func (c *FileService) UploadReader(projectID string, content io.Reader, file FileUpload, progress UploadProgress) (r FileUploadResponse, err error) {
+	file.setDefaults()
+	file.Data = streamDataToken
+
+	err = c.postStream(c.Ctx(), fmt.Sprintf("%s/%s/%s/%s", pathProjects, projectID, pathFiles, "upload"), &r, file, content, progress)
+	return
+}
+
 func (c *FileService) Download(projectID string, downloadOptions FileDownload) (r FileDownloadResponse, err error) {
 	url := fmt.Sprintf("%s/%s/%s/%s", pathProjects, projectID, pathFiles, "download")
 	resp, err := c.post(c.Ctx(), url, &r, downloadOptions)
@@ -186,6 +208,12 @@ type FileListOptions struct {
 	Limit    uint   `url:"limit,omitempty"`
 	Page     uint   `url:"page,omitempty"`
 	Filename string `url:"filter_filename,omitempty"`
+
+	// FilenameGlob lists the files of every page whose name matches, i.e. "locales/*.json", see path.Match.
+	// The pagination options are ignored then.
+	FilenameGlob string `url:"-"`
+	// PlatformKeyCounts sets File.KeyCounts, counted by listing the keys of the returned files.
+	PlatformKeyCounts bool `url:"-"`
 }
 
 func (options FileListOptions) Apply(req *resty.Request) {
@@ -193,9 +221,49 @@ func (options FileListOptions) Apply(req *resty.Request) {
 	req.SetQueryString(v.Encode())
 }
 
+func (f *FileUpload) setDefaults() {
+	if f.CustomTranslationStatusSkippedKeys == nil {
+		f.CustomTranslationStatusSkippedKeys = Bool(false)
+	}
+	if f.CustomTranslationStatusUpdatedKeys == nil {
+		f.CustomTranslationStatusUpdatedKeys = Bool(true)
+	}
+	if f.CustomTranslationStatusInsertedKeys == nil {
+		f.CustomTranslationStatusInsertedKeys = Bool(true)
+	}
+	f.Queue = true
+}
+
 func (c *FileService) ListOpts() FileListOptions        { return c.opts }
 func (c *FileService) SetListOptions(o FileListOptions) { c.opts = o }
 func (c *FileService) WithListOptions(o FileListOptions) *FileService {
 	c.opts = o
 	return c
 }
+
+// listGlob lists the files of every page matching FileListOptions.FilenameGlob.
+func (c *FileService) listGlob(projectID string, opts FileListOptions) (r FilesResponse, err error) {
+	pattern := opts.FilenameGlob
+	if _, err = path.Match(pattern, ""); err != nil {
+		return r, fmt.Errorf("lokalise: filename glob %q: %w", pattern, err)
+	}
+
+	opts.FilenameGlob = ""
+	opts.PlatformKeyCounts = false
+	opts.Limit = maxFilesPageLimit
+	s := *c
+	err = walkOffsetPages(func(page uint) (Paged, error) {
+		opts.Page = page
+		s.opts = opts
+		resp, err := s.List(projectID)
+		r.WithProjectID = resp.WithProjectID
+		for _, f := range resp.Files {
+			if ok, _ := path.Match(pattern, f.Filename); ok {
+				r.Files = append(r.Files, f)
+			}
+		}
+		return resp.Paged, err
+	})
+	r.Paged = Paged{TotalCount: int64(len(r.Files)), PageCount: 1, Limit: int64(len(r.Files)), Page: 1}
+	return
+}
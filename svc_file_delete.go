@@ -0,0 +1,221 @@
+package lokalise
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service request/response objects
+// _____________________________________________________________________________________________________________________
+
+type DeleteFileResponse struct {
+	WithProjectID
+	IsDeleted bool `json:"file_deleted"`
+}
+
+// FileDeletePreview lists the keys deleted along with a file.
+type FileDeletePreview struct {
+	Filename string
+	// KeyIDs are the keys assigned to the file on any platform.
+	KeyIDs []int64
+	// SharedKeyIDs are the keys of KeyIDs also assigned to other files on other platforms.
+	SharedKeyIDs []int64
+	// KeyCounts are the numbers of keys assigned to the file per platform.
+	KeyCounts map[string]int64
+}
+
+type FileDeleteOptions struct {
+	// DeleteShared deletes the keys also assigned to other files as well, see FileDeletePreview.SharedKeyIDs.
+	// By default they are kept and only unassigned from the file.
+	DeleteShared bool
+}
+
+type FileDeleteResult struct {
+	FileDeletePreview
+	// DeletedKeyIDs are the keys actually deleted.
+	DeletedKeyIDs []int64
+	// UnassignedKeyIDs are the shared keys kept, with the filename of the file cleared.
+	UnassignedKeyIDs []int64
+}
+
+// clearFilenamesKey updates the filenames of a key, sending empty filenames as well unlike NewKey.
+type clearFilenamesKey struct {
+	KeyID     int64             `json:"key_id"`
+	Filenames map[string]string `json:"filenames"`
+}
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service methods
+// _____________________________________________________________________________________________________________________
+
+// Delete deletes a file with its keys. The API only deletes files of document projects,
+// the files of other projects are deleted with DeleteByFilename.
+func (c *FileService) Delete(projectID string, fileID int64) (r DeleteFileResponse, err error) {
+	resp, err := c.delete(c.Ctx(), fmt.Sprintf("%s/%s/%s/%d", pathProjects, projectID, pathFiles, fileID), &r)
+
+	if err != nil {
+		return
+	}
+	return r, apiError(resp)
+}
+
+// PreviewDelete lists the keys DeleteByFilename would delete, without changing anything.
+func (c *FileService) PreviewDelete(projectID, filename string) (r FileDeletePreview, err error) {
+	keys, err := c.filesKeys(projectID, []string{filename})
+	if err != nil {
+		return
+	}
+	return newFileDeletePreview(filename, keys), nil
+}
+
+// DeleteByFilename deletes the keys assigned to a file, i.e. to clean up the files left over by a
+// restructuring of the repository. See PreviewDelete for the keys affected.
+// Unless DeleteShared is set, the shared keys are kept and the filename of the file is cleared on them,
+// so the file is gone either way.
+func (c *FileService) DeleteByFilename(projectID, filename string, opts FileDeleteOptions) (r FileDeleteResult, err error) {
+	keys, err := c.filesKeys(projectID, []string{filename})
+	if err != nil {
+		return
+	}
+	r.FileDeletePreview = newFileDeletePreview(filename, keys)
+
+	ids := r.KeyIDs
+	var unassign []clearFilenamesKey
+	if !opts.DeleteShared && len(r.SharedKeyIDs) > 0 {
+		shared := make(map[int64]bool, len(r.SharedKeyIDs))
+		for _, id := range r.SharedKeyIDs {
+			shared[id] = true
+		}
+		ids = nil
+		for _, id := range r.KeyIDs {
+			if !shared[id] {
+				ids = append(ids, id)
+			}
+		}
+		for _, k := range keys {
+			if shared[k.KeyID] {
+				unassign = append(unassign, clearFilename(k, filename))
+			}
+		}
+	}
+
+	for start := 0; start < len(unassign); start += keysBatchSize {
+		end := min(start+keysBatchSize, len(unassign))
+		if err = c.clearFilenames(projectID, unassign[start:end]); err != nil {
+			return
+		}
+		for _, k := range unassign[start:end] {
+			r.UnassignedKeyIDs = append(r.UnassignedKeyIDs, k.KeyID)
+		}
+	}
+
+	ks := KeyService{BaseService: c.BaseService}
+	for start := 0; start < len(ids); start += keysBatchSize {
+		end := min(start+keysBatchSize, len(ids))
+		if _, err = ks.BulkDelete(projectID, ids[start:end]); err != nil {
+			return
+		}
+		r.DeletedKeyIDs = append(r.DeletedKeyIDs, ids[start:end]...)
+	}
+	return r, nil
+}
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Additional methods
+// _____________________________________________________________________________________________________________________
+
+// countPlatformKeys sets the key counts per platform of the files, see FileListOptions.PlatformKeyCounts.
+func (c *FileService) countPlatformKeys(projectID string, files []File) error {
+	names := make([]string, len(files))
+	counts := make(map[string]map[string]int64, len(files))
+	for i := range files {
+		names[i] = files[i].Filename
+		files[i].KeyCounts = make(map[string]int64)
+		counts[files[i].Filename] = files[i].KeyCounts
+	}
+
+	keys, err := c.filesKeys(projectID, names)
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		for _, p := range []string{PlatformIos, PlatformAndroid, PlatformWeb, PlatformOther} {
+			if n, ok := counts[k.Filenames.For(p)]; ok {
+				n[p]++
+			}
+		}
+	}
+	return nil
+}
+
+// filesKeys lists the keys possibly assigned to the files with a single listing, the callers match
+// the filenames of the keys. The filter of the API splits filenames at commas, so the keys of such
+// files are found by listing the whole project once.
+func (c *FileService) filesKeys(projectID string, filenames []string) ([]Key, error) {
+	opts := KeyListOptions{FilterFilenames: strings.Join(filenames, ",")}
+	for _, f := range filenames {
+		if strings.Contains(f, ",") {
+			opts.FilterFilenames = ""
+			break
+		}
+	}
+	ks := KeyService{BaseService: c.BaseService}
+	return ks.listAll(projectID, opts)
+}
+
+// clearFilenames clears the filenames of the keys, see DeleteByFilename.
+func (c *FileService) clearFilenames(projectID string, keys []clearFilenamesKey) error {
+	var r KeysResponse
+	resp, err := c.put(c.Ctx(), fmt.Sprintf("%s/%s/%s", pathProjects, projectID, pathKeys), &r,
+		map[string]interface{}{"keys": keys})
+	if err != nil {
+		return err
+	}
+	if err = apiError(resp); err != nil {
+		return err
+	}
+	if len(r.Errors) > 0 {
+		return fmt.Errorf("lokalise: clearing the filename of key %s: %s", r.Errors[0].Key.KeyName, r.Errors[0].Message)
+	}
+	return nil
+}
+
+// clearFilename returns the filenames of the key with the filename cleared on every platform it is assigned to.
+// The filenames of the other platforms are sent as they are, so they are kept however the API merges them.
+func clearFilename(k Key, filename string) clearFilenamesKey {
+	u := clearFilenamesKey{KeyID: k.KeyID, Filenames: make(map[string]string)}
+	for _, p := range []string{PlatformIos, PlatformAndroid, PlatformWeb, PlatformOther} {
+		f := k.Filenames.For(p)
+		if f == filename {
+			f = ""
+		}
+		u.Filenames[p] = f
+	}
+	return u
+}
+
+func newFileDeletePreview(filename string, keys []Key) FileDeletePreview {
+	r := FileDeletePreview{Filename: filename, KeyCounts: make(map[string]int64)}
+	for _, k := range keys {
+		assigned, shared := false, false
+		for _, p := range []string{PlatformIos, PlatformAndroid, PlatformWeb, PlatformOther} {
+			switch k.Filenames.For(p) {
+			case filename:
+				assigned = true
+				r.KeyCounts[p]++
+			case "":
+			default:
+				shared = true
+			}
+		}
+		if !assigned {
+			continue
+		}
+		r.KeyIDs = append(r.KeyIDs, k.KeyID)
+		if shared {
+			r.SharedKeyIDs = append(r.SharedKeyIDs, k.KeyID)
+		}
+	}
+	return r
+}
@@ -0,0 +1,168 @@
+package lokalise
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const testFileKeys = `{
+	"keys": [
+		{"key_id": 1, "filenames": {"web": "old/en.json", "other": "old/en.json"}},
+		{"key_id": 2, "filenames": {"web": "old/en.json", "ios": "Localizable.strings"}},
+		{"key_id": 3, "filenames": {"web": "old/en.json.bak"}},
+		{"key_id": 4, "filenames": {"android": "strings.xml"}}
+	]
+}`
+
+func TestFileService_Delete(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/files/%d", testProjectID, 123),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "DELETE")
+			testHeader(t, r, apiTokenHeader, testApiToken)
+
+			_, _ = fmt.Fprint(w, `{"project_id": "`+testProjectID+`", "file_deleted": true}`)
+		})
+
+	r, err := client.Files().Delete(testProjectID, 123)
+	if err != nil {
+		t.Errorf("Files.Delete returned error: %v", err)
+	}
+
+	want := DeleteFileResponse{WithProjectID: WithProjectID{ProjectID: testProjectID}, IsDeleted: true}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf(assertionTemplate, "Files.Delete", r, want)
+	}
+}
+
+func TestFileService_DeleteByFilename(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/keys", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.Method {
+			case http.MethodGet:
+				if got := r.URL.Query().Get("filter_filenames"); got != "old/en.json" {
+					t.Errorf("filter_filenames = %q, want old/en.json", got)
+				}
+				_, _ = fmt.Fprint(w, testFileKeys)
+			case http.MethodPut:
+				testBody(t, r, `{"keys":[{"key_id":2,"filenames":{"android":"","ios":"Localizable.strings","other":"","web":""}}]}`)
+				_, _ = fmt.Fprint(w, `{"keys": [{"key_id": 2, "filenames": {"ios": "Localizable.strings"}}]}`)
+			case http.MethodDelete:
+				testBody(t, r, `{"keys":[1]}`)
+				_, _ = fmt.Fprint(w, `{"keys_removed": true}`)
+			}
+		})
+
+	preview := FileDeletePreview{
+		Filename:     "old/en.json",
+		KeyIDs:       []int64{1, 2},
+		SharedKeyIDs: []int64{2},
+		KeyCounts:    map[string]int64{PlatformWeb: 2, PlatformOther: 1},
+	}
+
+	p, err := client.Files().PreviewDelete(testProjectID, "old/en.json")
+	if err != nil {
+		t.Errorf("Files.PreviewDelete returned error: %v", err)
+	}
+	if !reflect.DeepEqual(p, preview) {
+		t.Errorf(assertionTemplate, "Files.PreviewDelete", p, preview)
+	}
+
+	r, err := client.Files().DeleteByFilename(testProjectID, "old/en.json", FileDeleteOptions{})
+	if err != nil {
+		t.Errorf("Files.DeleteByFilename returned error: %v", err)
+	}
+	want := FileDeleteResult{FileDeletePreview: preview, DeletedKeyIDs: []int64{1}, UnassignedKeyIDs: []int64{2}}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf(assertionTemplate, "Files.DeleteByFilename", r, want)
+	}
+}
+
+func TestFileService_PreviewDelete_Comma(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/keys", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if q := r.URL.Query(); q.Has("filter_filenames") {
+				t.Errorf("filter_filenames = %q, want none for a filename with a comma", q.Get("filter_filenames"))
+			}
+			_, _ = fmt.Fprint(w, `{
+				"keys": [
+					{"key_id": 1, "filenames": {"web": "a,b.json"}},
+					{"key_id": 2, "filenames": {"web": "b.json"}}
+				]
+			}`)
+		})
+
+	p, err := client.Files().PreviewDelete(testProjectID, "a,b.json")
+	if err != nil {
+		t.Errorf("Files.PreviewDelete returned error: %v", err)
+	}
+	want := FileDeletePreview{Filename: "a,b.json", KeyIDs: []int64{1}, KeyCounts: map[string]int64{PlatformWeb: 1}}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf(assertionTemplate, "Files.PreviewDelete", p, want)
+	}
+}
+
+func TestFileService_List_FilenameGlob(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/files", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if q := r.URL.Query(); q.Has("platform_key_counts") {
+				t.Errorf("client side options sent: %s", r.URL.RawQuery)
+			}
+			w.Header().Set("X-Pagination-Page-Count", "2")
+			if r.URL.Query().Get("page") == "1" {
+				_, _ = fmt.Fprint(w, `{"files": [{"file_id": 1, "filename": "old/en.json", "key_count": 2}, {"file_id": 2, "filename": "strings.xml", "key_count": 1}]}`)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"files": [{"file_id": 3, "filename": "old/en.json.bak", "key_count": 1}]}`)
+		})
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/keys", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if got := r.URL.Query().Get("filter_filenames"); got != "old/en.json,old/en.json.bak" {
+				t.Errorf("filter_filenames = %q", got)
+			}
+			_, _ = fmt.Fprint(w, testFileKeys)
+		})
+
+	r, err := client.Files().WithListOptions(FileListOptions{FilenameGlob: "old/*", PlatformKeyCounts: true}).List(testProjectID)
+	if err != nil {
+		t.Fatalf("Files.List returned error: %v", err)
+	}
+
+	want := FilesResponse{
+		Paged: Paged{TotalCount: 2, PageCount: 1, Limit: 2, Page: 1},
+		Files: []File{
+			{FileId: 1, Filename: "old/en.json", KeyCount: 2, KeyCounts: map[string]int64{PlatformWeb: 2, PlatformOther: 1}},
+			{FileId: 3, Filename: "old/en.json.bak", KeyCount: 1, KeyCounts: map[string]int64{PlatformWeb: 1}},
+		},
+	}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf(assertionTemplate, "Files.List", r, want)
+	}
+
+	if _, err := client.Files().WithListOptions(FileListOptions{FilenameGlob: "["}).List(testProjectID); err == nil {
+		t.Error("Files.List with a bad glob returned no error")
+	}
+}
@@ -0,0 +1,206 @@
+package lokalise
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Allowed values of the FileDownload options.
+//
+// noinspection GoUnusedConst
+const (
+	FormatAndroidSDK     FileFormat = "android_sdk"
+	FormatARB            FileFormat = "arb"
+	FormatCSV            FileFormat = "csv"
+	FormatDOCX           FileFormat = "docx"
+	FormatHTML           FileFormat = "html"
+	FormatINI            FileFormat = "ini"
+	FormatIOSSDK         FileFormat = "ios_sdk"
+	FormatJS             FileFormat = "js"
+	FormatJSON           FileFormat = "json"
+	FormatJSONStructured FileFormat = "json_structured"
+	FormatPHP            FileFormat = "php"
+	FormatPlist          FileFormat = "plist"
+	FormatPO             FileFormat = "po"
+	FormatProperties     FileFormat = "properties"
+	FormatRESJSON        FileFormat = "resjson"
+	FormatRESX           FileFormat = "resx"
+	FormatStrings        FileFormat = "strings"
+	FormatStringsDict    FileFormat = "stringsdict"
+	FormatTS             FileFormat = "ts"
+	FormatXCStrings      FileFormat = "xcstrings"
+	FormatXLF            FileFormat = "xlf"
+	FormatXLIFF          FileFormat = "xliff"
+	FormatXLSX           FileFormat = "xlsx"
+	FormatXML            FileFormat = "xml"
+	FormatYAML           FileFormat = "yaml"
+	FormatYML            FileFormat = "yml"
+
+	ExportSortFirstAdded  ExportSort = "first_added"
+	ExportSortLastAdded   ExportSort = "last_added"
+	ExportSortLastUpdated ExportSort = "last_updated"
+	ExportSortAZ          ExportSort = "a_z"
+	ExportSortZA          ExportSort = "z_a"
+
+	ExportEmptyAsEmpty ExportEmptyAs = "empty"
+	ExportEmptyAsBase  ExportEmptyAs = "base"
+	ExportEmptyAsSkip  ExportEmptyAs = "skip"
+
+	PlaceholderFormatPrintf  PlaceholderFormat = "printf"
+	PlaceholderFormatIOS     PlaceholderFormat = "ios"
+	PlaceholderFormatICU     PlaceholderFormat = "icu"
+	PlaceholderFormatNet     PlaceholderFormat = "net"
+	PlaceholderFormatSymfony PlaceholderFormat = "symfony"
+	PlaceholderFormatI18n    PlaceholderFormat = "i18n"
+	PlaceholderFormatRaw     PlaceholderFormat = "raw"
+
+	PluralFormatJSONString PluralFormat = "json_string"
+	PluralFormatICU        PluralFormat = "icu"
+	PluralFormatArray      PluralFormat = "array"
+	PluralFormatGeneric    PluralFormat = "generic"
+	PluralFormatSymfony    PluralFormat = "symfony"
+	PluralFormatI18next    PluralFormat = "i18next"
+	PluralFormatI18nextV4  PluralFormat = "i18next_v4"
+
+	IndentationDefault Indentation = "default"
+	Indentation1Space  Indentation = "1sp"
+	Indentation2Spaces Indentation = "2sp"
+	Indentation3Spaces Indentation = "3sp"
+	Indentation4Spaces Indentation = "4sp"
+	Indentation5Spaces Indentation = "5sp"
+	Indentation6Spaces Indentation = "6sp"
+	Indentation7Spaces Indentation = "7sp"
+	Indentation8Spaces Indentation = "8sp"
+	IndentationTab     Indentation = "tab"
+
+	JavaPropertiesUTF8   JavaPropertiesEncoding = "utf-8"
+	JavaPropertiesLatin1 JavaPropertiesEncoding = "latin-1"
+
+	JavaPropertiesSeparatorEquals JavaPropertiesSeparator = "="
+	JavaPropertiesSeparatorColon  JavaPropertiesSeparator = ":"
+
+	TriggerAmazonS3            ExportTrigger = "amazons3"
+	TriggerGCS                 ExportTrigger = "gcs"
+	TriggerGitHub              ExportTrigger = "github"
+	TriggerGitHubEnterprise    ExportTrigger = "github-enterprise"
+	TriggerGitLab              ExportTrigger = "gitlab"
+	TriggerBitbucket           ExportTrigger = "bitbucket"
+	TriggerBitbucketEnterprise ExportTrigger = "bitbucket-enterprise"
+	TriggerAzure               ExportTrigger = "azure"
+)
+
+type (
+	FileFormat              string
+	ExportSort              string
+	ExportEmptyAs           string
+	PlaceholderFormat       string
+	PluralFormat            string
+	Indentation             string
+	JavaPropertiesEncoding  string
+	JavaPropertiesSeparator string
+	ExportTrigger           string
+)
+
+var (
+	fileFormats = []FileFormat{
+		FormatAndroidSDK, FormatARB, FormatCSV, FormatDOCX, FormatHTML, FormatINI, FormatIOSSDK, FormatJS,
+		FormatJSON, FormatJSONStructured, FormatPHP, FormatPlist, FormatPO, FormatProperties, FormatRESJSON,
+		FormatRESX, FormatStrings, FormatStringsDict, FormatTS, FormatXCStrings, FormatXLF, FormatXLIFF,
+		FormatXLSX, FormatXML, FormatYAML, FormatYML,
+	}
+	exportSorts        = []ExportSort{ExportSortFirstAdded, ExportSortLastAdded, ExportSortLastUpdated, ExportSortAZ, ExportSortZA}
+	exportEmptyAs      = []ExportEmptyAs{ExportEmptyAsEmpty, ExportEmptyAsBase, ExportEmptyAsSkip}
+	placeholderFormats = []PlaceholderFormat{
+		PlaceholderFormatPrintf, PlaceholderFormatIOS, PlaceholderFormatICU, PlaceholderFormatNet,
+		PlaceholderFormatSymfony, PlaceholderFormatI18n, PlaceholderFormatRaw,
+	}
+	pluralFormats = []PluralFormat{
+		PluralFormatJSONString, PluralFormatICU, PluralFormatArray, PluralFormatGeneric,
+		PluralFormatSymfony, PluralFormatI18next, PluralFormatI18nextV4,
+	}
+	indentations = []Indentation{
+		IndentationDefault, Indentation1Space, Indentation2Spaces, Indentation3Spaces, Indentation4Spaces,
+		Indentation5Spaces, Indentation6Spaces, Indentation7Spaces, Indentation8Spaces, IndentationTab,
+	}
+	javaPropertiesEncodings  = []JavaPropertiesEncoding{JavaPropertiesUTF8, JavaPropertiesLatin1}
+	javaPropertiesSeparators = []JavaPropertiesSeparator{JavaPropertiesSeparatorEquals, JavaPropertiesSeparatorColon}
+	exportTriggers           = []ExportTrigger{
+		TriggerAmazonS3, TriggerGCS, TriggerGitHub, TriggerGitHubEnterprise,
+		TriggerGitLab, TriggerBitbucket, TriggerBitbucketEnterprise, TriggerAzure,
+	}
+)
+
+// Validate checks the options against the values known to the API and the per format rules, so that mistakes
+// are reported before the download is queued. All problems are reported at once. Validation is up to the caller:
+// Download and AsyncDownload send the options as they are, as the API may accept values added after this
+// version of the client.
+func (d FileDownload) Validate() error {
+	var problems []string
+	addf := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	switch {
+	case d.Format == "":
+		addf("format is required")
+	case !slices.Contains(fileFormats, FileFormat(d.Format)):
+		addf("unknown format %q", d.Format)
+	}
+	checkAllowed(addf, "export_sort", d.ExportSort, exportSorts)
+	checkAllowed(addf, "export_empty_as", d.ExportEmptyAs, exportEmptyAs)
+	checkAllowed(addf, "placeholder_format", d.PlaceholderFormat, placeholderFormats)
+	checkAllowed(addf, "plural_format", d.PluralFormat, pluralFormats)
+	checkAllowed(addf, "indentation", d.Indentation, indentations)
+	checkAllowed(addf, "java_properties_encoding", d.JavaPropertiesEncoding, javaPropertiesEncodings)
+	checkAllowed(addf, "java_properties_separator", d.JavaPropertiesSeparator, javaPropertiesSeparators)
+	for _, t := range d.Triggers {
+		checkAllowed(addf, "trigger", t, exportTriggers)
+	}
+	d.checkCombinations(addf)
+
+	return downloadProblems(problems)
+}
+
+func (d FileDownload) checkCombinations(addf func(string, ...any)) {
+	if d.YAMLIncludeRoot && d.Format != string(FormatYAML) && d.Format != string(FormatYML) {
+		addf("yaml_include_root is only supported by the yaml format")
+	}
+	if d.JSONUnescapedSlashes && d.Format != string(FormatJSON) && d.Format != string(FormatJSONStructured) {
+		addf("json_unescaped_slashes is only supported by the json formats")
+	}
+	if (d.JavaPropertiesEncoding != "" || d.JavaPropertiesSeparator != "") && d.Format != string(FormatProperties) {
+		addf("java_properties options are only supported by the properties format")
+	}
+	if d.EscapePercent && d.PlaceholderFormat != "" && d.PlaceholderFormat != string(PlaceholderFormatPrintf) {
+		addf("escape_percent only works with the printf placeholder format")
+	}
+	if d.ICUNumeric && d.PluralFormat != "" && d.PluralFormat != string(PluralFormatICU) {
+		addf("icu_numeric only works with the icu plural format")
+	}
+
+	if d.BundleStructure != "" && d.OriginalFilenames != nil && *d.OriginalFilenames {
+		addf("bundle_structure requires original_filenames to be false")
+	}
+	if d.DirectoryPrefix != nil && d.OriginalFilenames != nil && !*d.OriginalFilenames {
+		addf("directory_prefix requires original_filenames")
+	}
+	for i, m := range d.LanguageMapping {
+		if m.OriginalLangISO == "" || m.CustomLangISO == "" {
+			addf("language_mapping %d needs both language codes", i)
+		}
+	}
+}
+
+func downloadProblems(problems []string) error {
+	if len(problems) > 0 {
+		return fmt.Errorf("lokalise: invalid file download: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func checkAllowed[T ~string](addf func(string, ...any), name, value string, allowed []T) {
+	if value != "" && !slices.Contains(allowed, T(value)) {
+		addf("unknown %s %q", name, value)
+	}
+}
@@ -0,0 +1,68 @@
+package lokalise
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestFileDownload_Validate(t *testing.T) {
+	prefix := "%LANG_ISO%/"
+	valid := []FileDownload{
+		{Format: string(FormatJSON)},
+		{Format: string(FormatYAML), YAMLIncludeRoot: true, Indentation: string(Indentation2Spaces)},
+		{Format: string(FormatProperties), JavaPropertiesEncoding: string(JavaPropertiesLatin1), JavaPropertiesSeparator: string(JavaPropertiesSeparatorColon)},
+		{Format: string(FormatJSON), OriginalFilenames: Bool(false), BundleStructure: "%LANG_ISO%.json", PluralFormat: string(PluralFormatI18nextV4)},
+		{Format: string(FormatStrings), EscapePercent: true, ExportSort: string(ExportSortAZ), ExportEmptyAs: string(ExportEmptyAsBase), Triggers: []string{string(TriggerGitHub)}},
+		{Format: string(FormatARB), ICUNumeric: true, PluralFormat: string(PluralFormatICU), DirectoryPrefix: &prefix},
+		{Format: string(FormatJSON), BundleStructure: "%LANG_ISO%.json"},
+	}
+	for _, d := range valid {
+		if err := d.Validate(); err != nil {
+			t.Errorf("Validate(%+v) returned error: %v", d, err)
+		}
+	}
+
+	invalid := map[string]FileDownload{
+		"format is required":            {},
+		`unknown format "jsn"`:          {Format: "jsn"},
+		`unknown export_sort "newest"`:  {Format: string(FormatJSON), ExportSort: "newest"},
+		`unknown trigger "svn"`:         {Format: string(FormatJSON), Triggers: []string{string(TriggerGitLab), "svn"}},
+		`unknown indentation "2"`:       {Format: string(FormatJSON), Indentation: "2"},
+		"yaml_include_root":             {Format: string(FormatJSON), YAMLIncludeRoot: true},
+		"json_unescaped_slashes":        {Format: string(FormatYAML), JSONUnescapedSlashes: true},
+		"java_properties":               {Format: string(FormatJSON), JavaPropertiesEncoding: string(JavaPropertiesUTF8)},
+		"escape_percent":                {Format: string(FormatJSON), EscapePercent: true, PlaceholderFormat: string(PlaceholderFormatI18n)},
+		"icu_numeric":                   {Format: string(FormatJSON), ICUNumeric: true, PluralFormat: string(PluralFormatArray)},
+		"bundle_structure":              {Format: string(FormatJSON), OriginalFilenames: Bool(true), BundleStructure: "%LANG_ISO%.json"},
+		"directory_prefix":              {Format: string(FormatJSON), OriginalFilenames: Bool(false), DirectoryPrefix: &prefix},
+		"language_mapping 0 needs both": {Format: string(FormatJSON), LanguageMapping: []LanguageMapping{{OriginalLangISO: "en"}}},
+		`unknown placeholder_format "y"; unknown plural_format "x"`: {Format: string(FormatJSON), PluralFormat: "x", PlaceholderFormat: "y"},
+	}
+	for want, d := range invalid {
+		err := d.Validate()
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf(assertionTemplate, "Validate", err, want)
+		}
+	}
+}
+
+func TestFileService_Download_Unvalidated(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/files/download", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			testBody(t, r, `{"format":"flutter_sdk","triggers":["svn"],"yaml_include_root":true}`)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"project_id": "`+testProjectID+`", "bundle_url": "https://example.com/bundle.zip"}`)
+		})
+
+	// values unknown to the client and combinations it deems invalid are left to the API
+	r, err := client.Files().Download(testProjectID, FileDownload{Format: "flutter_sdk", Triggers: []string{"svn"}, YAMLIncludeRoot: true})
+	if err != nil || r.BundleURL == "" {
+		t.Errorf("Files.Download of unvalidated options: %+v, %v", r, err)
+	}
+}
@@ -0,0 +1,115 @@
+package lokalise
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ExportMode selects how FileService.Export generates the bundle.
+type ExportMode int
+
+const (
+	// ExportAuto downloads synchronously and falls back to an async download when the project is too big.
+	ExportAuto ExportMode = iota
+	// ExportSync only uses the synchronous download.
+	ExportSync
+	// ExportAsync always uses the async download and waits for its process.
+	ExportAsync
+)
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service request/response objects
+// _____________________________________________________________________________________________________________________
+
+type ExportOptions struct {
+	Mode ExportMode
+	// PollInterval is the delay before polling the async download process again, doubled after every poll. Default: 1s
+	PollInterval time.Duration
+	// MaxPollInterval caps the delay between polls. Default: 10s
+	MaxPollInterval time.Duration
+	// ExtractDir is the directory the bundle is extracted into, the bundle is not downloaded if empty.
+	ExtractDir string
+	Bundle     BundleOptions
+}
+
+type ExportResult struct {
+	BundleURL string
+	// Async is set when the bundle was generated by an async download process.
+	Async     bool
+	ProcessID string
+	// Warning is the warning of the synchronous download which led to the async download.
+	Warning string
+	// Bundle holds the extracted files when ExportOptions.ExtractDir is set.
+	Bundle BundleResult
+}
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service methods
+// _____________________________________________________________________________________________________________________
+
+// Export generates a bundle and returns its URL, extracting it if ExportOptions.ExtractDir is set.
+// In ExportAuto mode, the async download is used when the synchronous download reports the project
+// as too big, either with a warning or a 413 error. Waiting stops when the service context is done.
+func (c *FileService) Export(projectID string, download FileDownload, opts ExportOptions) (r ExportResult, err error) {
+	if opts.Mode != ExportAsync {
+		resp, err := c.Download(projectID, download)
+		switch {
+		case err != nil && !(opts.Mode == ExportAuto && isResponseTooBig(err)):
+			return r, err
+		case err == nil && (resp.Warning == "" || opts.Mode == ExportSync):
+			r.BundleURL, r.Warning = resp.BundleURL, resp.Warning
+		default:
+			r.Warning = resp.Warning
+			if r.Warning == "" {
+				r.Warning = err.Error()
+			}
+		}
+	}
+
+	switch {
+	case r.BundleURL != "":
+	case opts.Mode == ExportSync && r.Warning != "":
+		return r, fmt.Errorf("lokalise: download returned no bundle URL: %s", r.Warning)
+	case opts.Mode == ExportSync:
+		return r, errors.New("lokalise: download returned no bundle URL")
+	default:
+		if err = c.exportAsync(projectID, download, opts, &r); err != nil {
+			return
+		}
+	}
+
+	if opts.ExtractDir != "" {
+		r.Bundle, err = c.DownloadBundle(r.BundleURL, opts.ExtractDir, opts.Bundle)
+	}
+	return
+}
+
+func (c *FileService) exportAsync(projectID string, download FileDownload, opts ExportOptions, r *ExportResult) error {
+	resp, err := c.AsyncDownload(projectID, download)
+	if err != nil {
+		return err
+	}
+	r.Async, r.ProcessID = true, resp.ProcessID
+
+	qs := QueuedProcessService{BaseService: c.BaseService}
+	process, err := qs.WaitForProcess(c.Ctx(), projectID, resp.ProcessID, ProcessWaitOptions{
+		PollInterval:    opts.PollInterval,
+		MaxPollInterval: opts.MaxPollInterval,
+	})
+	if err != nil {
+		return err
+	}
+	if process.Details.DownloadUrl == "" {
+		return fmt.Errorf("lokalise: download process %s finished without a download URL", resp.ProcessID)
+	}
+	r.BundleURL = process.Details.DownloadUrl
+	return nil
+}
+
+// isResponseTooBig reports whether the synchronous download failed because the project is too big.
+func isResponseTooBig(err error) bool {
+	var apiErr Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusRequestEntityTooLarge
+}
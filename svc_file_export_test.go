@@ -0,0 +1,151 @@
+package lokalise
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFileService_Export(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	bundleURL := serverURL + baseURLPath + "/bundles/export.zip"
+	data := buildZip(t, zipEntry{name: "locale/en.json", content: `{"a":"A"}`})
+	mux.HandleFunc("/bundles/export.zip", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(data)
+	})
+
+	tooBig := true
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/files/download", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "POST")
+			testBody(t, r, `{"format":"json"}`)
+			if tooBig {
+				w.Header().Set("X-Response-Too-Big", "Project too big for sync export")
+			}
+			_, _ = fmt.Fprint(w, `{"project_id": "`+testProjectID+`", "bundle_url": "`+bundleURL+`?sync"}`)
+		})
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/files/async-download", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "POST")
+			testBody(t, r, `{"format":"json"}`)
+			_, _ = fmt.Fprint(w, `{"process_id": "p1"}`)
+		})
+	polls := 0
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/processes/p1", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			polls++
+			if polls == 1 {
+				_, _ = fmt.Fprint(w, `{"process": {"process_id": "p1", "type": "async-export", "status": "running"}}`)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"process": {"process_id": "p1", "type": "async-export", "status": "finished",
+				"details": {"download_url": "`+bundleURL+`"}}}`)
+		})
+
+	download := FileDownload{Format: "json"}
+	dir := t.TempDir()
+	r, err := client.Files().Export(testProjectID, download, ExportOptions{PollInterval: time.Millisecond, ExtractDir: dir})
+	if err != nil {
+		t.Fatalf("Files.Export returned error: %v", err)
+	}
+	want := ExportResult{
+		BundleURL: bundleURL,
+		Async:     true,
+		ProcessID: "p1",
+		Warning:   "Project too big for sync export",
+		Bundle:    BundleResult{Written: []BundleFile{{Path: "locale/en.json", LangISO: "en", Size: 9}}},
+	}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf(assertionTemplate, "Files.Export auto", r, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "locale", "en.json")); err != nil {
+		t.Errorf("bundle not extracted: %v", err)
+	}
+
+	r, err = client.Files().Export(testProjectID, download, ExportOptions{Mode: ExportSync})
+	if err != nil || r.Async || r.BundleURL != bundleURL+"?sync" || r.Warning == "" {
+		t.Errorf(assertionTemplate, "Files.Export sync", r, "sync bundle with warning")
+	}
+
+	tooBig = false
+	r, err = client.Files().Export(testProjectID, download, ExportOptions{})
+	if err != nil || r.Async || r.BundleURL != bundleURL+"?sync" {
+		t.Errorf(assertionTemplate, "Files.Export auto without warning", r, "sync bundle")
+	}
+
+	polls = 0
+	r, err = client.Files().Export(testProjectID, download, ExportOptions{Mode: ExportAsync, PollInterval: time.Millisecond})
+	if err != nil || !r.Async || r.BundleURL != bundleURL {
+		t.Errorf(assertionTemplate, "Files.Export async", r, "async bundle")
+	}
+}
+
+func TestFileService_Export_Fallbacks(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/files/download", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			_, _ = fmt.Fprint(w, `{"error": {"code": 413, "message": "Too big"}}`)
+		})
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/files/async-download", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"process_id": "p2"}`)
+		})
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/processes/p2", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"process": {"process_id": "p2", "status": "failed", "message": "Export failed"}}`)
+		})
+
+	r, err := client.Files().Export(testProjectID, FileDownload{Format: "json"}, ExportOptions{})
+	if err == nil || !r.Async || r.Warning != "API request error 413 Too big" {
+		t.Errorf(assertionTemplate, "Files.Export fallback", fmt.Sprint(r, err), "failed async export")
+	}
+
+	if _, err := client.Files().Export(testProjectID, FileDownload{Format: "json"}, ExportOptions{Mode: ExportSync}); err == nil {
+		t.Error("Files.Export sync of a too big project returned no error")
+	}
+}
+
+func TestFileService_Export_SyncWithoutBundle(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/files/download", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Response-Too-Big", "Project too big for sync export")
+			_, _ = fmt.Fprint(w, `{"project_id": "`+testProjectID+`"}`)
+		})
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/files/async-download", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			t.Error("Files.Export in sync mode started an async download")
+		})
+
+	r, err := client.Files().Export(testProjectID, FileDownload{Format: "json"}, ExportOptions{Mode: ExportSync})
+	if err == nil || r.Async || r.Warning != "Project too big for sync export" {
+		t.Errorf(assertionTemplate, "Files.Export sync", fmt.Sprint(r, err), "error without async download")
+	}
+}
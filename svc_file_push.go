@@ -0,0 +1,276 @@
+package lokalise
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// LangISOPlaceholder stands for the language code in push patterns and Lokalise filenames.
+const LangISOPlaceholder = "%LANG_ISO%"
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service request/response objects
+// _____________________________________________________________________________________________________________________
+
+type PushOptions struct {
+	// Patterns select the files to push, as slash separated paths relative to the directory.
+	// %LANG_ISO% matches the language code of the file, * and ? match within a path segment
+	// and **/ matches any number of directories, i.e. "locales/%LANG_ISO%/*.json" or "**/%LANG_ISO%.lproj/*.strings".
+	// The first matching pattern is used, files matching no pattern are left out. Patterns without
+	// %LANG_ISO% push the files in Defaults.LangISO.
+	Patterns []string
+	// Defaults are the upload options shared by every file, i.e. Tags, ReplaceModified or CleanupMode.
+	// Data, Filename and LangISO are set per file.
+	Defaults FileUpload
+	// Filename returns the filename of a file in the project. By default, it is the relative path
+	// with its language code replaced by %LANG_ISO%, so that all languages share the same file.
+	Filename func(path, langISO string) string
+	// Workers is the number of files uploaded concurrently, see defaultBulkWorkers. Default: 1
+	Workers int
+	Wait    UploadWaitOptions
+	// State records the content of the last successful uploads, unchanged files are skipped if set.
+	State UploadStateStore
+	// Force uploads every file, the State is still updated.
+	Force bool
+	// Progress is called after every file with the number of finished and total files. Calls are serialized.
+	Progress func(done, total int)
+}
+
+type PushFileResult struct {
+	// Path is relative to the pushed directory, with slashes.
+	Path     string
+	LangISO  string
+	Filename string
+	// Skipped is set when the content did not change since the last upload recorded in PushOptions.State.
+	Skipped bool
+	Summary FileUploadSummary
+	Err     error
+}
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service methods
+// _____________________________________________________________________________________________________________________
+
+// PushDir uploads the files of a directory matching the patterns and waits for every import, see UploadAndWait.
+// Files whose content did not change since their last upload are skipped when PushOptions.State is set.
+// Results are returned in path order. The error reports the number of failed files,
+// the failures themselves are set on the results.
+func (c *FileService) PushDir(projectID, dir string, opts PushOptions) (r []PushFileResult, err error) {
+	files, err := MatchPushFiles(dir, opts.Patterns, opts.Defaults.LangISO)
+	if err != nil {
+		return
+	}
+	filename := opts.Filename
+	if filename == nil {
+		filename = defaultPushFilename
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = defaultBulkWorkers
+	}
+	workers = min(workers, len(files))
+
+	r = make([]PushFileResult, len(files))
+	jobs := make(chan int)
+
+	var (
+		mu     sync.Mutex
+		done   int
+		failed int
+		wg     sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				res := files[i]
+				res.Filename = filename(res.Path, res.LangISO)
+				c.pushFile(projectID, dir, &res, opts)
+				r[i] = res
+
+				mu.Lock()
+				done++
+				if res.Err != nil {
+					failed++
+				}
+				if opts.Progress != nil {
+					opts.Progress(done, len(files))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if failed > 0 {
+		return r, fmt.Errorf("lokalise: %d of %d file uploads failed", failed, len(files))
+	}
+	return r, nil
+}
+
+// pushFile uploads a single file, unless its content matches the upload state.
+// The file is streamed, read once for its hash and once more for the upload.
+func (c *FileService) pushFile(projectID, dir string, res *PushFileResult, opts PushOptions) {
+	f, err := os.Open(filepath.Join(dir, filepath.FromSlash(res.Path)))
+	if err != nil {
+		res.Err = err
+		return
+	}
+	defer f.Close()
+
+	key := NewUploadStateKey(projectID, res.Filename, res.LangISO)
+	var hash string
+	if opts.State != nil {
+		if hash, err = uploadReaderHash(f); err == nil {
+			_, err = f.Seek(0, io.SeekStart)
+		}
+		if err != nil {
+			res.Err = err
+			return
+		}
+	}
+	if opts.State != nil && !opts.Force {
+		last, ok, err := opts.State.Get(key)
+		if err != nil {
+			res.Err = err
+			return
+		}
+		if ok && last == hash {
+			res.Skipped = true
+			return
+		}
+	}
+
+	upload := opts.Defaults
+	upload.Filename, upload.LangISO = res.Filename, res.LangISO
+	res.Summary, res.Err = c.UploadAndWait(projectID, f, upload, opts.Wait)
+	if res.Err == nil && opts.State != nil {
+		if err := opts.State.Put(key, hash); err != nil {
+			res.Err = fmt.Errorf("lokalise: record upload state: %w", err)
+		}
+	}
+}
+
+// MatchPushFiles walks dir and returns the files matching the patterns with their language,
+// see PushOptions.Patterns. defaultLangISO is the language of files matched by patterns without %LANG_ISO%.
+func MatchPushFiles(dir string, patterns []string, defaultLangISO string) ([]PushFileResult, error) {
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("lokalise: at least one push pattern is required")
+	}
+	compiled := make([]*regexp.Regexp, len(patterns))
+	for i, p := range patterns {
+		if !strings.Contains(p, LangISOPlaceholder) && defaultLangISO == "" {
+			return nil, fmt.Errorf("lokalise: push pattern %q has no %s and no default language is set", p, LangISOPlaceholder)
+		}
+		compiled[i] = compilePushPattern(p)
+	}
+
+	var files []PushFileResult
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		for _, re := range compiled {
+			if lang, ok := matchPushPattern(re, rel, defaultLangISO); ok {
+				files = append(files, PushFileResult{Path: rel, LangISO: lang})
+				break
+			}
+		}
+		return nil
+	})
+	return files, err
+}
+
+// matchPushPattern returns the language of a path matching a compiled push pattern.
+// All %LANG_ISO% placeholders of the pattern must match the same language.
+func matchPushPattern(re *regexp.Regexp, path, defaultLangISO string) (string, bool) {
+	m := re.FindStringSubmatch(path)
+	if m == nil {
+		return "", false
+	}
+	if len(m) == 1 {
+		return defaultLangISO, true
+	}
+	for _, lang := range m[2:] {
+		if lang != m[1] {
+			return "", false
+		}
+	}
+	return m[1], true
+}
+
+// compilePushPattern converts a push pattern to a regular expression, the language being the "lang" group.
+func compilePushPattern(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); {
+		rest := pattern[i:]
+		switch {
+		case strings.HasPrefix(rest, LangISOPlaceholder):
+			// later placeholders are unnamed groups, checked by matchPushPattern
+			if i == strings.Index(pattern, LangISOPlaceholder) {
+				b.WriteString(`(?P<lang>`)
+			} else {
+				b.WriteString(`(`)
+			}
+			b.WriteString(`[A-Za-z]{2,3}(?:[_-][A-Za-z0-9]{2,8})*)`)
+			i += len(LangISOPlaceholder)
+		case strings.HasPrefix(rest, "**/"):
+			b.WriteString(`(?:[^/]+/)*`)
+			i += 3
+		case rest[0] == '*':
+			b.WriteString(`[^/]*`)
+			i++
+		case rest[0] == '?':
+			b.WriteString(`[^/]`)
+			i++
+		default:
+			b.WriteString(regexp.QuoteMeta(rest[:1]))
+			i++
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// defaultPushFilename replaces the language code of the path with %LANG_ISO%.
+func defaultPushFilename(path, langISO string) string {
+	if langISO == "" {
+		return path
+	}
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if part == langISO {
+			parts[i] = LangISOPlaceholder
+			continue
+		}
+		ext := filepath.Ext(part)
+		stem := strings.TrimSuffix(part, ext)
+		switch {
+		case stem == langISO:
+			parts[i] = LangISOPlaceholder + ext
+		case strings.HasPrefix(part, langISO+"."):
+			parts[i] = LangISOPlaceholder + strings.TrimPrefix(part, langISO)
+		}
+	}
+	return strings.Join(parts, "/")
+}
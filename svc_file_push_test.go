@@ -0,0 +1,216 @@
+package lokalise
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func writePushTree(t *testing.T, files ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestMatchPushFiles(t *testing.T) {
+	dir := writePushTree(t,
+		"locales/en/app.json",
+		"locales/pt_BR/app.json",
+		"locales/en/notes.txt",
+		"ios/de.lproj/Localizable.strings",
+		"ios/Base.lproj/Main.storyboard",
+		"docs/en/en.md",
+		"docs/en/fr.md",
+		"README.md",
+	)
+
+	files, err := MatchPushFiles(dir, []string{
+		"locales/%LANG_ISO%/*.json",
+		"**/%LANG_ISO%.lproj/*.strings",
+		"docs/%LANG_ISO%/%LANG_ISO%.md",
+	}, "")
+	if err != nil {
+		t.Fatalf("MatchPushFiles returned error: %v", err)
+	}
+	want := []PushFileResult{
+		{Path: "docs/en/en.md", LangISO: "en"},
+		{Path: "ios/de.lproj/Localizable.strings", LangISO: "de"},
+		{Path: "locales/en/app.json", LangISO: "en"},
+		{Path: "locales/pt_BR/app.json", LangISO: "pt_BR"},
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf(assertionTemplate, "MatchPushFiles", files, want)
+	}
+
+	files, err = MatchPushFiles(dir, []string{"locales/en/*.json"}, "en")
+	if err != nil || len(files) != 1 || files[0].LangISO != "en" {
+		t.Errorf(assertionTemplate, "MatchPushFiles with default language", files, "locales/en/app.json")
+	}
+	if _, err := MatchPushFiles(dir, []string{"*.md"}, ""); err == nil {
+		t.Error("MatchPushFiles without language returned no error")
+	}
+}
+
+func TestDefaultPushFilename(t *testing.T) {
+	for path, want := range map[string]string{
+		"locales/en/app.json":              "locales/%LANG_ISO%/app.json",
+		"en.json":                          "%LANG_ISO%.json",
+		"ios/en.lproj/Localizable.strings": "ios/%LANG_ISO%.lproj/Localizable.strings",
+		"strings/app.json":                 "strings/app.json",
+	} {
+		if got := defaultPushFilename(path, "en"); got != want {
+			t.Errorf(assertionTemplate, path, got, want)
+		}
+	}
+}
+
+func TestFileService_PushDir(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	dir := writePushTree(t, "locales/en/app.json", "locales/de/app.json", "locales/fr/app.json")
+
+	var (
+		mu      sync.Mutex
+		uploads []FileUpload
+	)
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/files/upload", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "POST")
+
+			var upload FileUpload
+			if err := json.NewDecoder(r.Body).Decode(&upload); err != nil {
+				t.Errorf("decode upload: %v", err)
+			}
+			mu.Lock()
+			uploads = append(uploads, upload)
+			mu.Unlock()
+			_, _ = fmt.Fprint(w, `{"project_id": "`+testProjectID+`", "process": {"process_id": "p-`+upload.LangISO+`", "status": "queued"}}`)
+		})
+	for _, lang := range []string{"en", "de", "fr"} {
+		status, message := "finished", ""
+		if lang == "fr" {
+			status, message = "failed", "Invalid file"
+		}
+		mux.HandleFunc(
+			fmt.Sprintf("/projects/%s/processes/p-%s", testProjectID, lang),
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				testMethod(t, r, "GET")
+				_, _ = fmt.Fprint(w, `{"process": {"process_id": "p-`+lang+`", "status": "`+status+`", "message": "`+message+`",
+					"details": {"files": [{"status": "`+status+`", "key_count_inserted": 2}]}}}`)
+			})
+	}
+
+	var progress []int
+	r, err := client.Files().PushDir(testProjectID, dir, PushOptions{
+		Patterns: []string{"locales/%LANG_ISO%/*.json"},
+		Defaults: FileUpload{Tags: []string{"push"}, ReplaceModified: true, CleanupMode: true},
+		Workers:  2,
+		Wait:     UploadWaitOptions{PollInterval: time.Millisecond},
+		Progress: func(done, total int) { progress = append(progress, done) },
+	})
+	if err == nil || !strings.Contains(err.Error(), "1 of 3") {
+		t.Errorf(assertionTemplate, "Files.PushDir error", err, "1 of 3 file uploads failed")
+	}
+
+	if len(r) != 3 {
+		t.Fatalf(assertionTemplate, "Files.PushDir results", r, "3 results")
+	}
+	for i, lang := range []string{"de", "en", "fr"} {
+		res := r[i]
+		if res.Path != "locales/"+lang+"/app.json" || res.LangISO != lang || res.Filename != "locales/%LANG_ISO%/app.json" {
+			t.Errorf(assertionTemplate, "Files.PushDir result", res, lang)
+		}
+		if wantErr := lang == "fr"; (res.Err != nil) != wantErr {
+			t.Errorf(assertionTemplate, "Files.PushDir result error", res.Err, wantErr)
+		}
+	}
+	if r[0].Summary.KeysInserted != 2 || r[2].Summary.Message != "Invalid file" {
+		t.Errorf(assertionTemplate, "Files.PushDir summaries", r, "keys inserted and failure message")
+	}
+
+	sort.Slice(uploads, func(i, j int) bool { return uploads[i].LangISO < uploads[j].LangISO })
+	for _, u := range uploads {
+		if !reflect.DeepEqual(u.Tags, []string{"push"}) || !u.ReplaceModified || !u.CleanupMode || u.Data == "" {
+			t.Errorf(assertionTemplate, "upload defaults", u, "shared defaults")
+		}
+	}
+	if !reflect.DeepEqual(progress, []int{1, 2, 3}) {
+		t.Errorf(assertionTemplate, "progress", progress, []int{1, 2, 3})
+	}
+}
+
+func TestFileService_PushDir_State(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	dir := writePushTree(t, "en.json", "de.json")
+
+	uploads := map[string]int{}
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/files/upload", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			var upload FileUpload
+			if err := json.NewDecoder(r.Body).Decode(&upload); err != nil {
+				t.Errorf("decode upload: %v", err)
+			}
+			uploads[upload.LangISO]++
+			_, _ = fmt.Fprint(w, `{"process": {"process_id": "p1", "status": "queued"}}`)
+		})
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/processes/p1", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"process": {"process_id": "p1", "status": "finished"}}`)
+		})
+
+	state := NewMemoryUploadState()
+	opts := PushOptions{Patterns: []string{"%LANG_ISO%.json"}, State: state, Wait: UploadWaitOptions{PollInterval: time.Millisecond}}
+	push := func() []PushFileResult {
+		t.Helper()
+		r, err := client.Files().PushDir(testProjectID, dir, opts)
+		if err != nil {
+			t.Fatalf("Files.PushDir returned error: %v", err)
+		}
+		return r
+	}
+
+	push()
+	if err := os.WriteFile(filepath.Join(dir, "de.json"), []byte("changed"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	r := push()
+	if !reflect.DeepEqual(uploads, map[string]int{"en": 1, "de": 2}) {
+		t.Errorf(assertionTemplate, "uploads", uploads, "unchanged en.json skipped")
+	}
+	if r[0].Skipped || !r[1].Skipped {
+		t.Errorf(assertionTemplate, "Skipped", r, "de.json uploaded, en.json skipped")
+	}
+
+	opts.Force = true
+	push()
+	if !reflect.DeepEqual(uploads, map[string]int{"en": 2, "de": 3}) {
+		t.Errorf(assertionTemplate, "forced uploads", uploads, "all files uploaded")
+	}
+}
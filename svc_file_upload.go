@@ -0,0 +1,103 @@
+package lokalise
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service request/response objects
+// _____________________________________________________________________________________________________________________
+
+type UploadWaitOptions struct {
+	// PollInterval is the delay before polling the import process again, doubled after every poll. Default: 1s
+	PollInterval time.Duration
+	// MaxPollInterval caps the delay between polls. Default: 10s
+	MaxPollInterval time.Duration
+	// Progress is called while the content is uploaded.
+	Progress UploadProgress
+	// ProcessProgress is called after every poll of the import process.
+	ProcessProgress func(p ProcessProgress)
+}
+
+// FileUploadSummary is the outcome of an import process, key counts are the sums over its files.
+type FileUploadSummary struct {
+	ProcessID    string
+	Status       string
+	KeysInserted int64
+	KeysUpdated  int64
+	KeysSkipped  int64
+	// Message explains the failure of failed processes.
+	Message string
+	Files   []ProcessFile
+}
+
+// ProcessStatus returns the status of the import process, see the Process* constants.
+func (s FileUploadSummary) ProcessStatus() ProcessStatus { return ProcessStatus(s.Status) }
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service methods
+// _____________________________________________________________________________________________________________________
+
+// UploadAndWait uploads the content read from r and waits for the import to finish.
+// The content is streamed base64 encoded, see UploadReader. A *ProcessError is returned if the import failed or was
+// cancelled, along with the summary holding the failure message. Waiting stops when the service context is done.
+func (c *FileService) UploadAndWait(projectID string, r io.Reader, file FileUpload, opts UploadWaitOptions) (s FileUploadSummary, err error) {
+	resp, err := c.UploadReader(projectID, r, file, opts.Progress)
+	if err != nil {
+		return
+	}
+
+	qs := QueuedProcessService{BaseService: c.BaseService}
+	process, err := qs.WaitForProcess(c.Ctx(), projectID, resp.Process.ID, ProcessWaitOptions{
+		PollInterval:    opts.PollInterval,
+		MaxPollInterval: opts.MaxPollInterval,
+		Progress:        opts.ProcessProgress,
+	})
+	if process.ID == "" {
+		process.ID = resp.Process.ID
+	}
+	s = newFileUploadSummary(process)
+
+	var pe *ProcessError
+	if errors.As(err, &pe) && pe.Message == "" {
+		pe.Message = s.Message
+	}
+	return s, err
+}
+
+// UploadFileAndWait uploads a local file and waits for the import to finish, see UploadAndWait.
+// file.Filename defaults to the base name of the path.
+func (c *FileService) UploadFileAndWait(projectID, path string, file FileUpload, opts UploadWaitOptions) (s FileUploadSummary, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	if file.Filename == "" {
+		file.Filename = filepath.Base(path)
+	}
+	return c.UploadAndWait(projectID, f, file, opts)
+}
+
+func newFileUploadSummary(p QueuedProcess) FileUploadSummary {
+	s := FileUploadSummary{
+		ProcessID: p.ID,
+		Status:    p.Status,
+		Message:   p.Message,
+		Files:     p.Details.Files,
+	}
+	for _, f := range p.Details.Files {
+		s.KeysInserted += f.KeyCountInserted
+		s.KeysUpdated += f.KeyCountUpdated
+		s.KeysSkipped += f.KeyCountSkipped
+		if s.Message == "" && p.ProcessStatus() != ProcessFinished {
+			s.Message = f.Message
+		}
+	}
+	return s
+}
@@ -0,0 +1,109 @@
+package lokalise
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileService_UploadFileAndWait(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	const processID = "772a34cd"
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/files/upload", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "POST")
+
+			var upload FileUpload
+			if err := json.NewDecoder(r.Body).Decode(&upload); err != nil {
+				t.Fatalf("decode upload: %v", err)
+			}
+			if upload.Data != "eyJoaSI6ICJIaSJ9" || upload.Filename != "en.json" || upload.LangISO != "en" || !upload.Queue {
+				t.Errorf(assertionTemplate, "upload", upload, "base64 encoded en.json")
+			}
+			_, _ = fmt.Fprint(w, `{"project_id": "`+testProjectID+`", "process": {"process_id": "`+processID+`", "status": "queued"}}`)
+		})
+
+	polls := 0
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/processes/%s", testProjectID, processID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			polls++
+			if polls < 3 {
+				_, _ = fmt.Fprint(w, `{"process": {"process_id": "`+processID+`", "status": "running"}}`)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"process": {"process_id": "`+processID+`", "status": "finished", "details": {"files": [
+				{"status": "finished", "name_original": "en.json", "key_count_total": 6, "key_count_inserted": 3, "key_count_updated": 2, "key_count_skipped": 1}
+			]}}}`)
+		})
+
+	path := filepath.Join(t.TempDir(), "en.json")
+	if err := os.WriteFile(path, []byte(`{"hi": "Hi"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := client.Files().UploadFileAndWait(testProjectID, path, FileUpload{LangISO: "en"}, UploadWaitOptions{
+		PollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Files.UploadFileAndWait returned error: %v", err)
+	}
+
+	want := FileUploadSummary{
+		ProcessID:    processID,
+		Status:       string(ProcessFinished),
+		KeysInserted: 3,
+		KeysUpdated:  2,
+		KeysSkipped:  1,
+		Files: []ProcessFile{
+			{Status: "finished", NameOriginal: "en.json", KeyCountTotal: 6, KeyCountInserted: 3, KeyCountUpdated: 2, KeyCountSkipped: 1},
+		},
+	}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf(assertionTemplate, "Files.UploadFileAndWait", s, want)
+	}
+	if polls != 3 {
+		t.Errorf(assertionTemplate, "polls", polls, 3)
+	}
+}
+
+func TestFileService_UploadAndWait_Failed(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/files/upload", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"process": {"process_id": "p1", "status": "queued"}}`)
+		})
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/processes/p1", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"process": {"process_id": "p1", "status": "failed", "details": {"files": [
+				{"status": "failed", "message": "Invalid JSON", "name_original": "en.json"}
+			]}}}`)
+		})
+
+	s, err := client.Files().UploadAndWait(testProjectID, strings.NewReader("{"), FileUpload{Filename: "en.json", LangISO: "en"}, UploadWaitOptions{})
+	if err == nil || !strings.Contains(err.Error(), "Invalid JSON") {
+		t.Errorf(assertionTemplate, "UploadAndWait error", err, "Invalid JSON")
+	}
+	if s.ProcessStatus() != ProcessFailed || s.Message != "Invalid JSON" {
+		t.Errorf(assertionTemplate, "UploadAndWait summary", s, "failed with message")
+	}
+}
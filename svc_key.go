@@ -10,6 +10,9 @@ import (
 
 const (
 	pathKeys = "keys"
+
+	maxKeysPageLimit = 500
+	keysBatchSize    = 500
 )
 
 // The Key service
@@ -59,6 +62,28 @@ type PlatformStrings struct {
 	Other   string `json:"other,omitempty"`
 }
 
+const (
+	PlatformIos     = "ios"
+	PlatformAndroid = "android"
+	PlatformWeb     = "web"
+	PlatformOther   = "other"
+)
+
+// For returns the string of the given platform, or an empty string for unknown platforms.
+func (p PlatformStrings) For(platform string) string {
+	switch platform {
+	case PlatformIos:
+		return p.Ios
+	case PlatformAndroid:
+		return p.Android
+	case PlatformWeb:
+		return p.Web
+	case PlatformOther:
+		return p.Other
+	}
+	return ""
+}
+
 // ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
 // Service request/response objects
 // _____________________________________________________________________________________________________________________
@@ -376,3 +401,29 @@ func (c *KeyService) WithRetrieveOptions(o KeyRetrieveOptions) *KeyService {
 	c.retrieveOpts = o
 	return c
 }
+
+// listAll walks all the keys matching opts using cursor pagination.
+// The service list options are left untouched.
+func (c *KeyService) listAll(projectID string, opts KeyListOptions) ([]Key, error) {
+	opts.Pagination = PaginationCursor
+	opts.Page = 0
+	opts.Cursor = ""
+	if opts.Limit == 0 {
+		opts.Limit = maxKeysPageLimit
+	}
+
+	s := *c
+	var keys []Key
+	for {
+		s.listOpts = opts
+		r, err := s.List(projectID)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, r.Keys...)
+		if !r.HasNextCursor() {
+			return keys, nil
+		}
+		opts.Cursor = r.NextCursor()
+	}
+}
@@ -0,0 +1,534 @@
+package lokalise
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service request/response objects
+// _____________________________________________________________________________________________________________________
+
+// KeyConflictPolicy defines what happens when a copied key already exists in the destination project.
+type KeyConflictPolicy string
+
+const (
+	KeyConflictSkip      KeyConflictPolicy = "skip"
+	KeyConflictOverwrite KeyConflictPolicy = "overwrite"
+	KeyConflictRename    KeyConflictPolicy = "rename"
+)
+
+// KeyCopyAction is the outcome of copying a single key.
+type KeyCopyAction string
+
+const (
+	KeyCopyCreated     KeyCopyAction = "created"
+	KeyCopyOverwritten KeyCopyAction = "overwritten"
+	KeyCopyRenamed     KeyCopyAction = "renamed"
+	KeyCopySkipped     KeyCopyAction = "skipped"
+)
+
+const defaultKeyRenameSuffix = "_copy"
+
+type KeyCopyOptions struct {
+	// KeyIDs limits the copy to the given source keys, all keys are copied if empty.
+	KeyIDs []int64
+	// Filter is applied when listing the source keys, i.e. to select keys by tags or filenames.
+	// Pagination and include options are managed by the copy.
+	Filter KeyListOptions
+
+	// OnConflict defaults to KeyConflictSkip.
+	OnConflict KeyConflictPolicy
+	// RenameSuffix is appended to conflicting key names with KeyConflictRename. Default: _copy
+	RenameSuffix string
+
+	// Move deletes the source keys once they were copied. Skipped keys are never deleted.
+	Move bool
+
+	IncludeScreenshots bool
+	IncludeComments    bool
+
+	// LanguageMapping remaps source language ISO codes to the destination ones.
+	LanguageMapping []LanguageMapping
+
+	UseAutomations *bool
+}
+
+type CopiedKey struct {
+	SourceKeyID      int64
+	DestinationKeyID int64
+	KeyName          PlatformStrings
+	Action           KeyCopyAction
+}
+
+type KeyCopyReport struct {
+	Keys []CopiedKey
+	// Errors are the key level errors returned by the destination project.
+	Errors []ErrorKeys
+
+	ScreenshotsCreated int
+	SourceKeysDeleted  bool
+
+	// MissingLanguages are the (mapped) language ISO codes absent in the destination project,
+	// translations in these languages were not copied.
+	MissingLanguages []string
+	// MissingStatuses are the custom translation status titles absent in the destination project.
+	MissingStatuses []string
+}
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service methods
+// _____________________________________________________________________________________________________________________
+
+// Copy copies keys with their translations, and optionally comments and screenshots, from one project to another.
+// Branches are addressed with the `projectID:branch` notation for both projects.
+// Custom translation statuses are matched by title.
+func (c *KeyService) Copy(srcProjectID, dstProjectID string, opts KeyCopyOptions) (r KeyCopyReport, err error) {
+	if srcProjectID == dstProjectID {
+		return r, errors.New("lokalise: source and destination projects must differ")
+	}
+	if opts.OnConflict == "" {
+		opts.OnConflict = KeyConflictSkip
+	}
+	if opts.RenameSuffix == "" {
+		opts.RenameSuffix = defaultKeyRenameSuffix
+	}
+
+	listOpts := opts.Filter
+	listOpts.IncludeTranslations = 1
+	if opts.IncludeComments {
+		listOpts.IncludeComments = 1
+	}
+	if opts.IncludeScreenshots {
+		listOpts.IncludeScreenshots = 1
+	}
+	if len(opts.KeyIDs) > 0 {
+		listOpts.FilterKeyIDs = joinInt64(opts.KeyIDs)
+	}
+	srcKeys, err := c.listAll(srcProjectID, listOpts)
+	if err != nil {
+		return
+	}
+	dstKeys, err := c.listAll(dstProjectID, KeyListOptions{})
+	if err != nil {
+		return
+	}
+	existing := make(keyNameIndex, len(dstKeys))
+	for _, k := range dstKeys {
+		existing.add(k.KeyName, k.KeyID)
+	}
+
+	m, err := c.newKeyCopyMapper(dstProjectID, opts.LanguageMapping)
+	if err != nil {
+		return
+	}
+
+	var (
+		creates    []NewKey
+		createdSrc = make(map[PlatformStrings]Key)
+		createdAs  = make(map[PlatformStrings]KeyCopyAction)
+		updates    []BulkUpdateKey
+		updatedSrc = make(map[int64]Key)
+	)
+	for _, k := range srcKeys {
+		dstID, exists := existing.find(k.KeyName)
+		switch {
+		case !exists:
+			creates = append(creates, m.newKey(k, k.KeyName, opts))
+			createdSrc[k.KeyName] = k
+			createdAs[k.KeyName] = KeyCopyCreated
+		case opts.OnConflict == KeyConflictOverwrite:
+			updates = append(updates, BulkUpdateKey{KeyID: dstID, NewKey: m.newKey(k, k.KeyName, opts)})
+			updatedSrc[dstID] = k
+		case opts.OnConflict == KeyConflictRename:
+			name := renameKey(k.KeyName, opts.RenameSuffix, existing)
+			existing.add(name, 0)
+			creates = append(creates, m.newKey(k, name, opts))
+			createdSrc[name] = k
+			createdAs[name] = KeyCopyRenamed
+		default:
+			r.Keys = append(r.Keys, CopiedKey{
+				SourceKeyID:      k.KeyID,
+				DestinationKeyID: dstID,
+				KeyName:          k.KeyName,
+				Action:           KeyCopySkipped,
+			})
+		}
+	}
+
+	var keyOpts []KeyRequestOption
+	if opts.UseAutomations != nil {
+		keyOpts = append(keyOpts, WithAutomations(*opts.UseAutomations))
+	}
+
+	// source key ID -> destination key ID of every key actually copied
+	copied := make(map[int64]int64)
+
+	for start := 0; start < len(creates); start += keysBatchSize {
+		end := min(start+keysBatchSize, len(creates))
+		resp, err := c.Create(dstProjectID, creates[start:end], keyOpts...)
+		if err != nil {
+			return r, err
+		}
+		r.Errors = append(r.Errors, resp.Errors...)
+		for _, k := range resp.Keys {
+			src, ok := createdSrc[k.KeyName]
+			if !ok {
+				continue
+			}
+			copied[src.KeyID] = k.KeyID
+			r.Keys = append(r.Keys, CopiedKey{
+				SourceKeyID:      src.KeyID,
+				DestinationKeyID: k.KeyID,
+				KeyName:          k.KeyName,
+				Action:           createdAs[k.KeyName],
+			})
+		}
+	}
+
+	for start := 0; start < len(updates); start += keysBatchSize {
+		end := min(start+keysBatchSize, len(updates))
+		resp, err := c.BulkUpdate(dstProjectID, updates[start:end], keyOpts...)
+		if err != nil {
+			return r, err
+		}
+		r.Errors = append(r.Errors, resp.Errors...)
+		for _, k := range resp.Keys {
+			src, ok := updatedSrc[k.KeyID]
+			if !ok {
+				continue
+			}
+			copied[src.KeyID] = k.KeyID
+			r.Keys = append(r.Keys, CopiedKey{
+				SourceKeyID:      src.KeyID,
+				DestinationKeyID: k.KeyID,
+				KeyName:          k.KeyName,
+				Action:           KeyCopyOverwritten,
+			})
+		}
+	}
+
+	r.MissingLanguages = m.missingLanguages()
+	r.MissingStatuses = m.missingStatuses()
+
+	if opts.IncludeScreenshots {
+		r.ScreenshotsCreated, err = c.copyScreenshots(dstProjectID, srcKeys, copied)
+		if err != nil {
+			return r, err
+		}
+	}
+
+	if opts.Move && len(copied) > 0 {
+		r.SourceKeysDeleted = true
+		ids := make([]int64, 0, len(copied))
+		for id := range copied {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+		for start := 0; start < len(ids); start += keysBatchSize {
+			end := min(start+keysBatchSize, len(ids))
+			resp, err := c.BulkDelete(srcProjectID, ids[start:end])
+			if err != nil {
+				return r, err
+			}
+			r.SourceKeysDeleted = r.SourceKeysDeleted && resp.AreRemoved
+		}
+	}
+
+	return r, nil
+}
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Additional methods
+// _____________________________________________________________________________________________________________________
+
+// keyCopyMapper converts source keys into the destination project's languages and statuses.
+type keyCopyMapper struct {
+	langs     map[string]string
+	dstLangs  map[string]bool
+	statuses  map[string]int64
+	noLang    map[string]bool
+	noStatus  map[string]bool
+	hasStatus bool
+}
+
+func (c *KeyService) newKeyCopyMapper(dstProjectID string, mapping []LanguageMapping) (*keyCopyMapper, error) {
+	m := &keyCopyMapper{
+		langs:    make(map[string]string, len(mapping)),
+		dstLangs: make(map[string]bool),
+		statuses: make(map[string]int64),
+		noLang:   make(map[string]bool),
+		noStatus: make(map[string]bool),
+	}
+	for _, lm := range mapping {
+		m.langs[lm.OriginalLangISO] = lm.CustomLangISO
+	}
+
+	ls := LanguageService{c.BaseService}
+	ls.Limit = maxLanguagesPageLimit
+	err := walkOffsetPages(func(page uint) (Paged, error) {
+		ls.Page = page
+		resp, err := ls.ListProject(dstProjectID)
+		for _, l := range resp.Languages {
+			m.dstLangs[l.LangISO] = true
+		}
+		return resp.Paged, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ts := TranslationStatusService{c.BaseService}
+	ts.Limit = maxTranslationStatusesPageLimit
+	err = walkOffsetPages(func(page uint) (Paged, error) {
+		ts.Page = page
+		resp, err := ts.List(dstProjectID)
+		for _, s := range resp.TranslationStatuses {
+			m.statuses[s.Title] = s.StatusID
+		}
+		return resp.Paged, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *keyCopyMapper) newKey(k Key, name PlatformStrings, opts KeyCopyOptions) NewKey {
+	nk := NewKey{
+		KeyName:     newKeyName(name),
+		IsPlural:    Bool(k.IsPlural),
+		IsHidden:    Bool(k.IsHidden),
+		IsArchived:  Bool(k.IsArchived),
+		Description: &k.Description,
+		Filenames:   &k.Filenames,
+	}
+	if k.Platforms != nil {
+		nk.Platforms = &k.Platforms
+	}
+	if k.Tags != nil {
+		nk.Tags = &k.Tags
+	}
+	if k.PluralName != "" {
+		nk.PluralName = &k.PluralName
+	}
+	if k.Context != "" {
+		nk.Context = &k.Context
+	}
+	if k.CharLimit > 0 {
+		nk.CharLimit = &k.CharLimit
+	}
+	if k.CustomAttributes != "" {
+		nk.CustomAttributes = &k.CustomAttributes
+	}
+
+	translations := make([]NewTranslation, 0, len(k.Translations))
+	for _, t := range k.Translations {
+		if t.Translation == "" {
+			continue
+		}
+		iso := t.LanguageISO
+		if mapped, ok := m.langs[iso]; ok {
+			iso = mapped
+		}
+		if !m.dstLangs[iso] {
+			m.noLang[iso] = true
+			continue
+		}
+		nt := NewTranslation{
+			LanguageISO: iso,
+			Translation: t.Translation,
+			IsFuzzy:     Bool(t.IsUnverified),
+			IsReviewed:  t.IsReviewed,
+		}
+		for _, s := range t.CustomTranslationStatuses {
+			id, ok := m.statuses[s.Title]
+			if !ok {
+				m.noStatus[s.Title] = true
+				continue
+			}
+			nt.CustomTranslationStatusIds = append(nt.CustomTranslationStatusIds, id)
+		}
+		translations = append(translations, nt)
+	}
+	nk.Translations = &translations
+
+	if opts.IncludeComments && len(k.Comments) > 0 {
+		comments := make([]NewComment, 0, len(k.Comments))
+		for _, cm := range k.Comments {
+			comments = append(comments, NewComment{Comment: cm.Comment})
+		}
+		nk.Comments = &comments
+	}
+
+	return nk
+}
+
+func (m *keyCopyMapper) missingLanguages() []string { return sortedSet(m.noLang) }
+func (m *keyCopyMapper) missingStatuses() []string  { return sortedSet(m.noStatus) }
+
+// copyScreenshots recreates the screenshots of the copied keys in the destination project.
+// Screenshots shared by several keys are uploaded once.
+func (c *KeyService) copyScreenshots(dstProjectID string, srcKeys []Key, copied map[int64]int64) (int, error) {
+	var (
+		order   []int64
+		shots   = make(map[int64]Screenshot)
+		keysFor = make(map[int64][]int64)
+	)
+	for _, k := range srcKeys {
+		dstID, ok := copied[k.KeyID]
+		if !ok {
+			continue
+		}
+		for _, s := range k.Screenshots {
+			if _, seen := shots[s.ScreenshotID]; !seen {
+				order = append(order, s.ScreenshotID)
+				shots[s.ScreenshotID] = s
+			}
+			keysFor[s.ScreenshotID] = append(keysFor[s.ScreenshotID], dstID)
+		}
+	}
+	if len(order) == 0 {
+		return 0, nil
+	}
+
+	news := make([]NewScreenshot, 0, len(order))
+	for _, id := range order {
+		s := shots[id]
+		body, err := c.fetchDataURI(s.URL)
+		if err != nil {
+			return 0, fmt.Errorf("lokalise: fetch screenshot %d: %w", id, err)
+		}
+		news = append(news, NewScreenshot{
+			Body:        body,
+			Title:       s.Title,
+			Description: s.Description,
+			KeyIDs:      keysFor[id],
+			Tags:        s.ScreenshotTags,
+		})
+	}
+
+	ss := ScreenshotService{BaseService: c.BaseService}
+	resp, err := ss.Create(dstProjectID, news)
+	if err != nil {
+		return 0, err
+	}
+	return len(resp.Screenshots), nil
+}
+
+// fetchDataURI downloads a public asset and returns it as a base64 data URI.
+// The plain HTTP client is used so that the API token is not sent to third parties.
+func (c *KeyService) fetchDataURI(url string) (string, error) {
+	req, err := http.NewRequestWithContext(c.Ctx(), http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	res, err := c.GetClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s", res.Status)
+	}
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+	mime := res.Header.Get("Content-Type")
+	if mime == "" {
+		mime = http.DetectContentType(data)
+	}
+	return "data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// newKeyName returns the plain name when it is the same for every platform.
+func newKeyName(name PlatformStrings) interface{} {
+	if name.Ios == name.Android && name.Android == name.Web && name.Web == name.Other {
+		return name.Web
+	}
+	return name
+}
+
+// keyNameIndex maps the name of a key on each platform to its ID. Key names are unique per platform,
+// so two keys conflict as soon as they share the name on one platform.
+type keyNameIndex map[platformName]int64
+
+type platformName struct {
+	platform string
+	name     string
+}
+
+func (idx keyNameIndex) add(name PlatformStrings, id int64) {
+	for _, p := range []string{PlatformIos, PlatformAndroid, PlatformWeb, PlatformOther} {
+		if n := name.For(p); n != "" {
+			idx[platformName{p, n}] = id
+		}
+	}
+}
+
+// find returns the ID of the first key conflicting with the name, in platform order.
+func (idx keyNameIndex) find(name PlatformStrings) (int64, bool) {
+	for _, p := range []string{PlatformIos, PlatformAndroid, PlatformWeb, PlatformOther} {
+		if n := name.For(p); n != "" {
+			if id, ok := idx[platformName{p, n}]; ok {
+				return id, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// renameKey appends the suffix, and a counter if needed, to every platform name until it is unique.
+func renameKey(name PlatformStrings, suffix string, taken keyNameIndex) PlatformStrings {
+	for i := 1; ; i++ {
+		s := suffix
+		if i > 1 {
+			s += strconv.Itoa(i)
+		}
+		renamed := PlatformStrings{
+			Ios:     appendNonEmpty(name.Ios, s),
+			Android: appendNonEmpty(name.Android, s),
+			Web:     appendNonEmpty(name.Web, s),
+			Other:   appendNonEmpty(name.Other, s),
+		}
+		if _, ok := taken.find(renamed); !ok {
+			return renamed
+		}
+	}
+}
+
+func appendNonEmpty(s, suffix string) string {
+	if s == "" {
+		return s
+	}
+	return s + suffix
+}
+
+func joinInt64(ids []int64) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = strconv.FormatInt(id, 10)
+	}
+	return strings.Join(s, ",")
+}
+
+func sortedSet(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	s := make([]string, 0, len(set))
+	for v := range set {
+		s = append(s, v)
+	}
+	sort.Strings(s)
+	return s
+}
@@ -0,0 +1,270 @@
+package lokalise
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestKeyService_Copy(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	dstProjectID := "98765432109876543210.12345678:develop"
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/keys", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			if got := r.URL.Query().Get("include_translations"); got != "1" {
+				t.Errorf("include_translations = %q, want 1", got)
+			}
+			if got := r.URL.Query().Get("filter_key_ids"); got != "1,2" {
+				t.Errorf("filter_key_ids = %q, want 1,2", got)
+			}
+
+			_, _ = fmt.Fprint(w, `{
+				"keys": [
+					{
+						"key_id": 1,
+						"key_name": {"ios": "welcome", "android": "welcome", "web": "welcome", "other": "welcome"},
+						"tags": ["common"],
+						"translations": [
+							{"language_iso": "en", "translation": "Welcome", "is_reviewed": true,
+							 "custom_translation_statuses": [{"status_id": 11, "title": "Approved"}]},
+							{"language_iso": "lv", "translation": "Laipni lūdzam"},
+							{"language_iso": "de", "translation": ""}
+						]
+					},
+					{
+						"key_id": 2,
+						"key_name": {"ios": "bye", "android": "bye", "web": "bye", "other": "bye"},
+						"translations": [
+							{"language_iso": "en", "translation": "Bye"}
+						]
+					}
+				]
+			}`)
+		})
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/keys", dstProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch r.Method {
+			case http.MethodGet:
+				_, _ = fmt.Fprint(w, `{
+					"keys": [
+						{"key_id": 200, "key_name": {"ios": "bye", "android": "bye", "web": "bye", "other": "bye"}}
+					]
+				}`)
+			case http.MethodPost:
+				data := `{
+					"keys": [
+						{
+							"description": "",
+							"filenames": {},
+							"is_archived": false,
+							"is_hidden": false,
+							"is_plural": false,
+							"key_name": "welcome",
+							"tags": ["common"],
+							"translations": [
+								{"language_iso": "en", "translation": "Welcome", "is_fuzzy": false, "is_reviewed": true, "custom_translation_status_ids": [77]},
+								{"language_iso": "lv_LV", "translation": "Laipni lūdzam", "is_fuzzy": false}
+							]
+						},
+						{
+							"description": "",
+							"filenames": {},
+							"is_archived": false,
+							"is_hidden": false,
+							"is_plural": false,
+							"key_name": "bye_copy",
+							"translations": [
+								{"language_iso": "en", "translation": "Bye", "is_fuzzy": false}
+							]
+						}
+					]
+				}`
+				req := new(bytes.Buffer)
+				_ = json.Compact(req, []byte(data))
+				testBody(t, r, req.String())
+
+				_, _ = fmt.Fprint(w, `{
+					"keys": [
+						{"key_id": 301, "key_name": {"ios": "welcome", "android": "welcome", "web": "welcome", "other": "welcome"}},
+						{"key_id": 302, "key_name": {"ios": "bye_copy", "android": "bye_copy", "web": "bye_copy", "other": "bye_copy"}}
+					]
+				}`)
+			default:
+				t.Errorf("unexpected method %s", r.Method)
+			}
+		})
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/languages", dstProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			_, _ = fmt.Fprint(w, `{"languages": [{"lang_iso": "en"}, {"lang_iso": "lv_LV"}]}`)
+		})
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/custom_translation_statuses", dstProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			_, _ = fmt.Fprint(w, `{"custom_translation_statuses": [{"status_id": 77, "title": "Approved"}]}`)
+		})
+
+	r, err := client.Keys().Copy(testProjectID, dstProjectID, KeyCopyOptions{
+		KeyIDs:          []int64{1, 2},
+		OnConflict:      KeyConflictRename,
+		LanguageMapping: []LanguageMapping{{OriginalLangISO: "lv", CustomLangISO: "lv_LV"}},
+	})
+	if err != nil {
+		t.Errorf("Keys.Copy returned error: %v", err)
+	}
+
+	want := KeyCopyReport{
+		Keys: []CopiedKey{
+			{
+				SourceKeyID:      1,
+				DestinationKeyID: 301,
+				KeyName:          PlatformStrings{Ios: "welcome", Android: "welcome", Web: "welcome", Other: "welcome"},
+				Action:           KeyCopyCreated,
+			},
+			{
+				SourceKeyID:      2,
+				DestinationKeyID: 302,
+				KeyName:          PlatformStrings{Ios: "bye_copy", Android: "bye_copy", Web: "bye_copy", Other: "bye_copy"},
+				Action:           KeyCopyRenamed,
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf(assertionTemplate, "Keys.Copy", r, want)
+	}
+}
+
+func TestKeyService_Copy_Skip(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	dstProjectID := "98765432109876543210.12345678"
+
+	keys := `{
+		"keys": [
+			{"key_id": 5, "key_name": {"ios": "bye", "android": "bye", "web": "bye", "other": "bye"}}
+		]
+	}`
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/keys", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			_, _ = fmt.Fprint(w, keys)
+		})
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/keys", dstProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			_, _ = fmt.Fprint(w, keys)
+		})
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/languages", dstProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"languages": []}`)
+		})
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/custom_translation_statuses", dstProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"custom_translation_statuses": []}`)
+		})
+
+	r, err := client.Keys().Copy(testProjectID, dstProjectID, KeyCopyOptions{Move: true})
+	if err != nil {
+		t.Errorf("Keys.Copy returned error: %v", err)
+	}
+
+	want := KeyCopyReport{
+		Keys: []CopiedKey{
+			{
+				SourceKeyID:      5,
+				DestinationKeyID: 5,
+				KeyName:          PlatformStrings{Ios: "bye", Android: "bye", Web: "bye", Other: "bye"},
+				Action:           KeyCopySkipped,
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf(assertionTemplate, "Keys.Copy", r, want)
+	}
+}
+
+func TestKeyService_Copy_PlatformConflict(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	dstProjectID := "98765432109876543210.12345678"
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/keys", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"keys": [{"key_id": 5, "key_name": {"ios": "title", "web": "page.title"}}]}`)
+		})
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/keys", dstProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			_, _ = fmt.Fprint(w, `{"keys": [{"key_id": 9, "key_name": {"ios": "title", "web": "header.title"}}]}`)
+		})
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/languages", dstProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if got := r.URL.Query().Get("limit"); got != "5000" {
+				t.Errorf("limit = %q, want 5000", got)
+			}
+			_, _ = fmt.Fprint(w, `{"languages": []}`)
+		})
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/custom_translation_statuses", dstProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"custom_translation_statuses": []}`)
+		})
+
+	r, err := client.Keys().Copy(testProjectID, dstProjectID, KeyCopyOptions{})
+	if err != nil {
+		t.Errorf("Keys.Copy returned error: %v", err)
+	}
+
+	want := KeyCopyReport{
+		Keys: []CopiedKey{
+			{
+				SourceKeyID:      5,
+				DestinationKeyID: 9,
+				KeyName:          PlatformStrings{Ios: "title", Web: "page.title"},
+				Action:           KeyCopySkipped,
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf(assertionTemplate, "Keys.Copy", r, want)
+	}
+}
@@ -0,0 +1,276 @@
+package lokalise
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+)
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service request/response objects
+// _____________________________________________________________________________________________________________________
+
+type DuplicateKeysOptions struct {
+	// LangISO is the language compared between keys. Defaults to the base language of every project.
+	LangISO string
+	// Similarity is the minimal score, between 0 and 1, for texts to be considered near duplicates.
+	// Only identical texts (ignoring case and whitespace) are grouped when it is 0 or 1.
+	Similarity float64
+	// ProposeMerges adds a merge proposal to every group.
+	ProposeMerges bool
+	// Filter is applied when listing the keys of every project, i.e. to exclude archived keys.
+	Filter KeyListOptions
+}
+
+type DuplicateKey struct {
+	WithProjectID
+	KeyID   int64
+	KeyName PlatformStrings
+	Tags    []string
+	Context string
+	Text    string
+
+	// TranslatedLanguages is only counted when merges are proposed.
+	TranslatedLanguages int
+	CreatedAtTs         int64
+}
+
+// KeyMergeProposal suggests to keep one key of a project and merge the other keys into it.
+// Keys with a different context are never proposed for merging, as the context is usually set on purpose.
+type KeyMergeProposal struct {
+	WithProjectID
+	Keep  DuplicateKey
+	Merge []DuplicateKey
+}
+
+type DuplicateKeysGroup struct {
+	// Text is the most frequent text of the group.
+	Text string
+	// Similarity is the lowest score that joined the group, 1 for identical texts.
+	Similarity float64
+	Keys       []DuplicateKey
+	// Contexts lists the distinct non empty contexts set on the keys of the group.
+	Contexts []string
+	Merges   []KeyMergeProposal
+}
+
+type DuplicateKeysReport struct {
+	KeysAnalyzed int
+	Groups       []DuplicateKeysGroup
+}
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service methods
+// _____________________________________________________________________________________________________________________
+
+// FindDuplicates groups the keys of one or several projects having identical or near identical texts.
+func (c *KeyService) FindDuplicates(projectIDs []string, opts DuplicateKeysOptions) (r DuplicateKeysReport, err error) {
+	if len(projectIDs) == 0 {
+		return r, errors.New("lokalise: at least one project is required")
+	}
+
+	var keys []DuplicateKey
+	for _, projectID := range projectIDs {
+		found, err := c.duplicateCandidates(projectID, opts)
+		if err != nil {
+			return r, err
+		}
+		keys = append(keys, found...)
+	}
+	r.KeysAnalyzed = len(keys)
+	r.Groups = groupDuplicateKeys(keys, opts.Similarity)
+
+	if opts.ProposeMerges {
+		for i := range r.Groups {
+			r.Groups[i].Merges = proposeKeyMerges(r.Groups[i].Keys)
+		}
+	}
+	return r, nil
+}
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Additional methods
+// _____________________________________________________________________________________________________________________
+
+// duplicateCandidates lists the keys of the project having a text in the compared language.
+func (c *KeyService) duplicateCandidates(projectID string, opts DuplicateKeysOptions) ([]DuplicateKey, error) {
+	listOpts := opts.Filter
+	listOpts.IncludeTranslations = 1
+
+	lang := opts.LangISO
+	if lang == "" {
+		ps := ProjectService{BaseService: c.BaseService}
+		project, err := ps.Retrieve(projectID)
+		if err != nil {
+			return nil, err
+		}
+		lang = project.BaseLangISO
+		if !opts.ProposeMerges {
+			listOpts.FilterTranslationLangIDs = strconv.FormatInt(project.BaseLangID, 10)
+		}
+	}
+
+	keys, err := c.listAll(projectID, listOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make([]DuplicateKey, 0, len(keys))
+	for _, k := range keys {
+		d := DuplicateKey{
+			WithProjectID: WithProjectID{ProjectID: projectID},
+			KeyID:         k.KeyID,
+			KeyName:       k.KeyName,
+			Tags:          k.Tags,
+			Context:       k.Context,
+			CreatedAtTs:   k.CreatedAtTs,
+		}
+		for _, t := range k.Translations {
+			if t.Translation == "" {
+				continue
+			}
+			d.TranslatedLanguages++
+			if t.LanguageISO == lang {
+				d.Text = t.Translation
+			}
+		}
+		if !opts.ProposeMerges {
+			d.TranslatedLanguages = 0
+		}
+		if d.Text != "" {
+			found = append(found, d)
+		}
+	}
+	return found, nil
+}
+
+// groupDuplicateKeys groups identical normalized texts first, then joins the groups
+// whose texts are at least minSimilarity similar.
+func groupDuplicateKeys(keys []DuplicateKey, minSimilarity float64) []DuplicateKeysGroup {
+	var texts []string
+	byText := make(map[string][]DuplicateKey)
+	for _, k := range keys {
+		n := normalizeText(k.Text)
+		if _, ok := byText[n]; !ok {
+			texts = append(texts, n)
+		}
+		byText[n] = append(byText[n], k)
+	}
+
+	parent := make([]int, len(texts))
+	score := make([]float64, len(texts))
+	for i := range parent {
+		parent[i] = i
+		score[i] = 1
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	if minSimilarity > 0 && minSimilarity < 1 {
+		for i := range texts {
+			for j := i + 1; j < len(texts); j++ {
+				if maxSimilarity(texts[i], texts[j]) < minSimilarity {
+					continue
+				}
+				s := similarity(texts[i], texts[j])
+				if s < minSimilarity {
+					continue
+				}
+				ri, rj := find(i), find(j)
+				if ri != rj {
+					parent[rj] = ri
+					score[ri] = min(score[ri], score[rj], s)
+				}
+			}
+		}
+	}
+
+	members := make(map[int][]int)
+	var roots []int
+	for i := range texts {
+		root := find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], i)
+	}
+
+	var groups []DuplicateKeysGroup
+	for _, root := range roots {
+		g := DuplicateKeysGroup{Similarity: score[root]}
+		frequency := 0
+		contexts := make(map[string]bool)
+		for _, i := range members[root] {
+			ks := byText[texts[i]]
+			if len(ks) > frequency {
+				frequency = len(ks)
+				g.Text = ks[0].Text
+			}
+			for _, k := range ks {
+				if k.Context != "" {
+					contexts[k.Context] = true
+				}
+			}
+			g.Keys = append(g.Keys, ks...)
+		}
+		if len(g.Keys) < 2 {
+			continue
+		}
+		g.Contexts = sortedSet(contexts)
+		groups = append(groups, g)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if len(groups[i].Keys) != len(groups[j].Keys) {
+			return len(groups[i].Keys) > len(groups[j].Keys)
+		}
+		return groups[i].Text < groups[j].Text
+	})
+	return groups
+}
+
+// proposeKeyMerges keeps, in every project, the most translated key of the group, then the oldest one.
+func proposeKeyMerges(keys []DuplicateKey) []KeyMergeProposal {
+	var projects []string
+	byProject := make(map[string][]DuplicateKey)
+	for _, k := range keys {
+		if _, ok := byProject[k.ProjectID]; !ok {
+			projects = append(projects, k.ProjectID)
+		}
+		byProject[k.ProjectID] = append(byProject[k.ProjectID], k)
+	}
+
+	var proposals []KeyMergeProposal
+	for _, projectID := range projects {
+		candidates := byProject[projectID]
+		if len(candidates) < 2 {
+			continue
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			a, b := candidates[i], candidates[j]
+			if a.TranslatedLanguages != b.TranslatedLanguages {
+				return a.TranslatedLanguages > b.TranslatedLanguages
+			}
+			if a.CreatedAtTs != b.CreatedAtTs {
+				return a.CreatedAtTs < b.CreatedAtTs
+			}
+			return a.KeyID < b.KeyID
+		})
+
+		p := KeyMergeProposal{WithProjectID: WithProjectID{ProjectID: projectID}, Keep: candidates[0]}
+		for _, k := range candidates[1:] {
+			if k.Context == p.Keep.Context {
+				p.Merge = append(p.Merge, k)
+			}
+		}
+		if len(p.Merge) > 0 {
+			proposals = append(proposals, p)
+		}
+	}
+	return proposals
+}
@@ -0,0 +1,127 @@
+package lokalise
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestKeyService_FindDuplicates(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			_, _ = fmt.Fprint(w, `{
+				"project_id": "`+testProjectID+`",
+				"base_language_id": 640,
+				"base_language_iso": "en"
+			}`)
+		})
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/keys", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			if got := r.URL.Query().Get("include_translations"); got != "1" {
+				t.Errorf("include_translations = %q, want 1", got)
+			}
+
+			_, _ = fmt.Fprint(w, `{
+				"keys": [
+					{
+						"key_id": 1,
+						"key_name": {"ios": "save", "android": "save", "web": "save", "other": "save"},
+						"created_at_timestamp": 100,
+						"translations": [
+							{"language_iso": "en", "translation": "Save"},
+							{"language_iso": "de", "translation": "Speichern"}
+						]
+					},
+					{
+						"key_id": 2,
+						"key_name": {"ios": "btn_save", "android": "btn_save", "web": "btn_save", "other": "btn_save"},
+						"tags": ["buttons"],
+						"created_at_timestamp": 50,
+						"translations": [
+							{"language_iso": "en", "translation": "save"}
+						]
+					},
+					{
+						"key_id": 3,
+						"key_name": {"ios": "save_game", "android": "save_game", "web": "save_game", "other": "save_game"},
+						"context": "game menu",
+						"translations": [
+							{"language_iso": "en", "translation": "Save!"}
+						]
+					},
+					{
+						"key_id": 4,
+						"key_name": {"ios": "cancel", "android": "cancel", "web": "cancel", "other": "cancel"},
+						"translations": [
+							{"language_iso": "en", "translation": "Cancel"}
+						]
+					}
+				]
+			}`)
+		})
+
+	r, err := client.Keys().FindDuplicates([]string{testProjectID}, DuplicateKeysOptions{
+		Similarity:    0.8,
+		ProposeMerges: true,
+	})
+	if err != nil {
+		t.Errorf("Keys.FindDuplicates returned error: %v", err)
+	}
+
+	project := WithProjectID{ProjectID: testProjectID}
+	save := DuplicateKey{
+		WithProjectID:       project,
+		KeyID:               1,
+		KeyName:             PlatformStrings{Ios: "save", Android: "save", Web: "save", Other: "save"},
+		Text:                "Save",
+		TranslatedLanguages: 2,
+		CreatedAtTs:         100,
+	}
+	btnSave := DuplicateKey{
+		WithProjectID:       project,
+		KeyID:               2,
+		KeyName:             PlatformStrings{Ios: "btn_save", Android: "btn_save", Web: "btn_save", Other: "btn_save"},
+		Tags:                []string{"buttons"},
+		Text:                "save",
+		TranslatedLanguages: 1,
+		CreatedAtTs:         50,
+	}
+	saveGame := DuplicateKey{
+		WithProjectID:       project,
+		KeyID:               3,
+		KeyName:             PlatformStrings{Ios: "save_game", Android: "save_game", Web: "save_game", Other: "save_game"},
+		Context:             "game menu",
+		Text:                "Save!",
+		TranslatedLanguages: 1,
+	}
+
+	want := DuplicateKeysReport{
+		KeysAnalyzed: 4,
+		Groups: []DuplicateKeysGroup{
+			{
+				Text:       "Save",
+				Similarity: 0.8,
+				Keys:       []DuplicateKey{save, btnSave, saveGame},
+				Contexts:   []string{"game menu"},
+				Merges: []KeyMergeProposal{
+					{WithProjectID: project, Keep: save, Merge: []DuplicateKey{btnSave}},
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf(assertionTemplate, "Keys.FindDuplicates", r, want)
+	}
+}
@@ -0,0 +1,404 @@
+package lokalise
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service request/response objects
+// _____________________________________________________________________________________________________________________
+
+// KeyImportAction is the outcome of importing a single key.
+type KeyImportAction string
+
+const (
+	KeyImportInserted  KeyImportAction = "inserted"
+	KeyImportUpdated   KeyImportAction = "updated"
+	KeyImportSkipped   KeyImportAction = "skipped"
+	KeyImportUnchanged KeyImportAction = "unchanged"
+	KeyImportDeleted   KeyImportAction = "deleted"
+)
+
+type ImportedKey struct {
+	KeyID   int64
+	KeyName string
+	Action  KeyImportAction
+}
+
+type KeyImportReport struct {
+	Keys []ImportedKey
+	// Errors are the key level errors returned by the project.
+	Errors []ErrorKeys
+}
+
+// Count returns the number of keys imported with the given action.
+func (r KeyImportReport) Count(action KeyImportAction) int {
+	n := 0
+	for _, k := range r.Keys {
+		if k.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service methods
+// _____________________________________________________________________________________________________________________
+
+// Import applies the translations of a local file in the language file.LangISO through the Keys API,
+// without going through the file upload queue. The keys are the decoded content of the file, i.e. read
+// with the formats package, and must all be named for the same platform. Without keys, the platform is
+// resolved from the extension of file.Filename, so that CleanupMode still applies. file.Data is ignored.
+//
+// The import follows the FileUpload options: keys are matched by name, and by file.Filename as well with
+// DistinguishByFile. New keys are created, empty translations are filled and modified translations are only
+// replaced with ReplaceModified, the other keys being skipped. Tags, KeysToValues, HiddenFromContributors,
+// CleanupMode, UseAutomations and the custom translation status options apply as for uploads.
+// ApplyTM and DetectICUPlurals are not supported.
+func (c *KeyService) Import(projectID string, file FileUpload, keys []Key) (r KeyImportReport, err error) {
+	switch {
+	case file.LangISO == "":
+		return r, errors.New("lokalise: import language is required")
+	case file.ApplyTM:
+		return r, errors.New("lokalise: apply_tm is not supported when importing keys")
+	case file.DetectICUPlurals:
+		return r, errors.New("lokalise: detect_icu_plurals is not supported when importing keys")
+	case file.CleanupMode && file.Filename == "":
+		return r, errors.New("lokalise: cleanup mode requires a filename")
+	}
+	if len(keys) == 0 && !file.CleanupMode {
+		return r, nil
+	}
+	platform, err := importPlatform(keys)
+	if err != nil {
+		return
+	}
+	if platform == "" {
+		if platform = filenamePlatform(file.Filename); platform == "" {
+			return r, fmt.Errorf("lokalise: no platform for the format of %s", file.Filename)
+		}
+	}
+
+	existing, err := c.listAll(projectID, KeyListOptions{IncludeTranslations: 1, FilterPlatforms: platform})
+	if err != nil {
+		return
+	}
+	p := newKeyImportPlan(platform, file, existing)
+	for _, k := range keys {
+		p.add(k)
+	}
+
+	var keyOpts []KeyRequestOption
+	if file.UseAutomations != nil {
+		keyOpts = append(keyOpts, WithAutomations(*file.UseAutomations))
+	}
+
+	for start := 0; start < len(p.creates); start += keysBatchSize {
+		end := min(start+keysBatchSize, len(p.creates))
+		resp, err := c.Create(projectID, p.creates[start:end], keyOpts...)
+		if err != nil {
+			return r, err
+		}
+		r.Errors = append(r.Errors, resp.Errors...)
+		for _, k := range resp.Keys {
+			p.done(k.KeyName.For(platform), k.KeyID)
+		}
+	}
+
+	for start := 0; start < len(p.updates); start += keysBatchSize {
+		end := min(start+keysBatchSize, len(p.updates))
+		resp, err := c.BulkUpdate(projectID, p.updates[start:end], keyOpts...)
+		if err != nil {
+			return r, err
+		}
+		r.Errors = append(r.Errors, resp.Errors...)
+		for _, k := range resp.Keys {
+			p.done(k.KeyName.For(platform), k.KeyID)
+		}
+	}
+
+	if file.CleanupMode {
+		p.cleanup(existing)
+		for start := 0; start < len(p.deletes); start += keysBatchSize {
+			end := min(start+keysBatchSize, len(p.deletes))
+			ids := make([]int64, end-start)
+			for i, k := range p.deletes[start:end] {
+				ids[i] = k.KeyID
+			}
+			if _, err := c.BulkDelete(projectID, ids); err != nil {
+				return r, err
+			}
+			for _, k := range p.deletes[start:end] {
+				p.report = append(p.report, importedKey{ImportedKey: ImportedKey{
+					KeyID:   k.KeyID,
+					KeyName: k.KeyName.For(platform),
+					Action:  KeyImportDeleted,
+				}, done: true})
+			}
+		}
+	}
+
+	for _, k := range p.report {
+		if k.done {
+			r.Keys = append(r.Keys, k.ImportedKey)
+		}
+	}
+	return r, nil
+}
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Additional methods
+// _____________________________________________________________________________________________________________________
+
+// importPlatform returns the platform the keys are named for.
+func importPlatform(keys []Key) (string, error) {
+	platform := ""
+	for _, k := range keys {
+		p := keyPlatform(k)
+		if p == "" {
+			return "", fmt.Errorf("lokalise: no platform for imported key %+v", k.KeyName)
+		}
+		if platform != "" && p != platform {
+			return "", fmt.Errorf("lokalise: imported keys mix platforms %s and %s", platform, p)
+		}
+		platform = p
+	}
+	return platform, nil
+}
+
+// filenamePlatform returns the platform of the keys of a file format, based on the extension of the filename.
+func filenamePlatform(filename string) string {
+	switch strings.TrimPrefix(strings.ToLower(path.Ext(filename)), ".") {
+	case "strings", "stringsdict", "xcstrings", "plist":
+		return PlatformIos
+	case "xml":
+		return PlatformAndroid
+	case "json", "po", "pot", "xlf", "xliff", "yml", "yaml":
+		return PlatformWeb
+	case "arb", "properties":
+		return PlatformOther
+	}
+	return ""
+}
+
+// keyPlatform returns the only platform of a key, or the only platform it is named for.
+func keyPlatform(k Key) string {
+	if len(k.Platforms) == 1 {
+		return k.Platforms[0]
+	}
+	platform := ""
+	for _, p := range []string{PlatformIos, PlatformAndroid, PlatformWeb, PlatformOther} {
+		if k.KeyName.For(p) == "" {
+			continue
+		}
+		if platform != "" {
+			return ""
+		}
+		platform = p
+	}
+	return platform
+}
+
+type importedKey struct {
+	ImportedKey
+	// done is set once the change was applied.
+	done bool
+}
+
+// keyImportPlan sorts the imported keys into the requests to send.
+type keyImportPlan struct {
+	platform string
+	file     FileUpload
+	// existing maps names to the keys named so, as key names are only unique per file with DistinguishByFile.
+	existing map[string][]Key
+	names    map[string]bool
+	seen     map[int64]bool
+
+	creates []NewKey
+	updates []BulkUpdateKey
+	deletes []Key
+	report  []importedKey
+	// pending maps the names of created and updated keys to their report entry.
+	pending map[string]int
+}
+
+func newKeyImportPlan(platform string, file FileUpload, existing []Key) *keyImportPlan {
+	p := &keyImportPlan{
+		platform: platform,
+		file:     file,
+		existing: make(map[string][]Key, len(existing)),
+		names:    make(map[string]bool),
+		seen:     make(map[int64]bool),
+		pending:  make(map[string]int),
+	}
+	for _, k := range existing {
+		if file.DistinguishByFile && k.Filenames.For(platform) != file.Filename {
+			continue
+		}
+		if name := k.KeyName.For(platform); name != "" {
+			p.existing[name] = append(p.existing[name], k)
+		}
+	}
+	return p
+}
+
+func (p *keyImportPlan) add(k Key) {
+	name := k.KeyName.For(p.platform)
+	value, ok := importTranslation(k, p.file.LangISO)
+	if !ok || p.names[name] {
+		return
+	}
+	p.names[name] = true
+	if p.file.KeysToValues {
+		value = name
+	}
+
+	named := p.existing[name]
+	if len(named) == 0 {
+		p.insert(k, name, value)
+		return
+	}
+	// the first key is updated, the others are kept from cleanup
+	for _, ex := range named {
+		p.seen[ex.KeyID] = true
+	}
+	ex := named[0]
+
+	current, _ := importTranslation(ex, p.file.LangISO)
+	switch {
+	case sameTranslation(current, value, ex.IsPlural):
+		p.report = append(p.report, importedKey{ImportedKey: ImportedKey{KeyID: ex.KeyID, KeyName: name, Action: KeyImportUnchanged}, done: true})
+	case current == "" || p.file.ReplaceModified:
+		p.update(ex, name, value, KeyImportUpdated, optionalBool(p.file.TagUpdatedKeys, true), optionalBool(p.file.CustomTranslationStatusUpdatedKeys, true))
+	default:
+		p.update(ex, name, current, KeyImportSkipped, p.file.TagSkippedKeys, optionalBool(p.file.CustomTranslationStatusSkippedKeys, false))
+	}
+}
+
+func (p *keyImportPlan) insert(k Key, name, value string) {
+	nk := NewKey{
+		KeyName:   name,
+		IsPlural:  Bool(k.IsPlural),
+		Platforms: &[]string{p.platform},
+	}
+	if p.file.Filename != "" {
+		filenames := platformStrings(p.platform, p.file.Filename)
+		nk.Filenames = &filenames
+	}
+	if k.Description != "" {
+		nk.Description = &k.Description
+	}
+	if k.Context != "" {
+		nk.Context = &k.Context
+	}
+	if k.PluralName != "" {
+		nk.PluralName = &k.PluralName
+	}
+	if p.file.HiddenFromContributors {
+		nk.IsHidden = Bool(true)
+	}
+	if len(p.file.Tags) > 0 && optionalBool(p.file.TagInsertedKeys, true) {
+		nk.Tags = &p.file.Tags
+	}
+	t := NewTranslation{LanguageISO: p.file.LangISO, Translation: value}
+	if optionalBool(p.file.CustomTranslationStatusInsertedKeys, true) {
+		t.CustomTranslationStatusIds = p.file.CustomTranslationStatusIds
+	}
+	nk.Translations = &[]NewTranslation{t}
+
+	p.pending[name] = len(p.report)
+	p.report = append(p.report, importedKey{ImportedKey: ImportedKey{KeyName: name, Action: KeyImportInserted}})
+	p.creates = append(p.creates, nk)
+}
+
+// update changes the translation of an existing key to value, tagging it and setting the custom
+// translation statuses if requested. Skipped keys without tags or statuses to set are not sent.
+func (p *keyImportPlan) update(ex Key, name, value string, action KeyImportAction, tag, status bool) {
+	tag = tag && len(p.file.Tags) > 0
+	status = status && len(p.file.CustomTranslationStatusIds) > 0
+	if action == KeyImportSkipped && !tag && !status {
+		p.report = append(p.report, importedKey{ImportedKey: ImportedKey{KeyID: ex.KeyID, KeyName: name, Action: action}, done: true})
+		return
+	}
+
+	uk := BulkUpdateKey{KeyID: ex.KeyID}
+	if tag {
+		uk.Tags = &p.file.Tags
+		uk.MergeTags = Bool(true)
+	}
+	if action == KeyImportUpdated || status {
+		t := NewTranslation{LanguageISO: p.file.LangISO, Translation: value}
+		if status {
+			t.CustomTranslationStatusIds = p.file.CustomTranslationStatusIds
+			t.MergeCustomTranslationStatuses = true
+		}
+		uk.Translations = &[]NewTranslation{t}
+	}
+
+	p.pending[name] = len(p.report)
+	p.report = append(p.report, importedKey{ImportedKey: ImportedKey{KeyID: ex.KeyID, KeyName: name, Action: action}})
+	p.updates = append(p.updates, uk)
+}
+
+// done marks the key sent for the name as applied.
+func (p *keyImportPlan) done(name string, keyID int64) {
+	i, ok := p.pending[name]
+	if !ok {
+		return
+	}
+	p.report[i].KeyID = keyID
+	p.report[i].done = true
+}
+
+// cleanup selects the keys of the file missing from the import for deletion.
+func (p *keyImportPlan) cleanup(existing []Key) {
+	for _, k := range existing {
+		if k.Filenames.For(p.platform) == p.file.Filename && !p.seen[k.KeyID] {
+			p.deletes = append(p.deletes, k)
+		}
+	}
+}
+
+// importTranslation returns the translation of a key in the language.
+func importTranslation(k Key, langISO string) (string, bool) {
+	i := slices.IndexFunc(k.Translations, func(t Translation) bool { return t.LanguageISO == langISO })
+	if i < 0 {
+		return "", false
+	}
+	return k.Translations[i].Translation, true
+}
+
+// sameTranslation compares translations, plural ones regardless of the order and formatting of their forms.
+func sameTranslation(a, b string, plural bool) bool {
+	if a == b || !plural {
+		return a == b
+	}
+	pa, errA := ParsePluralTranslation(a)
+	pb, errB := ParsePluralTranslation(b)
+	return errA == nil && errB == nil && reflect.DeepEqual(pa, pb)
+}
+
+func platformStrings(platform, s string) PlatformStrings {
+	switch platform {
+	case PlatformIos:
+		return PlatformStrings{Ios: s}
+	case PlatformAndroid:
+		return PlatformStrings{Android: s}
+	case PlatformWeb:
+		return PlatformStrings{Web: s}
+	}
+	return PlatformStrings{Other: s}
+}
+
+func optionalBool(v *bool, def bool) bool {
+	if v == nil {
+		return def
+	}
+	return *v
+}
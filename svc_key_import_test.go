@@ -0,0 +1,238 @@
+package lokalise
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestKeyService_Import(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	compact := func(s string) string {
+		b := new(bytes.Buffer)
+		_ = json.Compact(b, []byte(s))
+		return b.String()
+	}
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/keys", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch r.Method {
+			case http.MethodGet:
+				if got := r.URL.Query().Get("filter_platforms"); got != "web" {
+					t.Errorf("filter_platforms = %q, want web", got)
+				}
+				_, _ = fmt.Fprint(w, `{
+					"keys": [
+						{"key_id": 1, "key_name": {"web": "welcome"}, "filenames": {"web": "en.json"},
+						 "translations": [{"language_iso": "en", "translation": "Welcome"}]},
+						{"key_id": 2, "key_name": {"web": "bye"}, "filenames": {"web": "en.json"},
+						 "translations": [{"language_iso": "en", "translation": "Bye"}]},
+						{"key_id": 3, "key_name": {"web": "empty"}, "filenames": {"web": "en.json"},
+						 "translations": [{"language_iso": "en", "translation": ""}]},
+						{"key_id": 4, "key_name": {"web": "items"}, "filenames": {"web": "en.json"}, "is_plural": true,
+						 "translations": [{"language_iso": "en", "translation": "{\"other\": \"{n} items\", \"one\": \"{n} item\"}"}]},
+						{"key_id": 5, "key_name": {"web": "stale"}, "filenames": {"web": "en.json"}},
+						{"key_id": 6, "key_name": {"web": "other"}, "filenames": {"web": "common.json"}}
+					]
+				}`)
+			case http.MethodPost:
+				testBody(t, r, compact(`{"keys": [{
+					"description": "Greeting",
+					"filenames": {"web": "en.json"},
+					"is_plural": false,
+					"key_name": "hello",
+					"platforms": ["web"],
+					"tags": ["import"],
+					"translations": [{"language_iso": "en", "translation": "Hello"}]
+				}]}`))
+				_, _ = fmt.Fprint(w, `{"keys": [{"key_id": 10, "key_name": {"web": "hello"}}]}`)
+			case http.MethodPut:
+				testBody(t, r, compact(`{"keys": [{
+					"key_id": 3,
+					"merge_tags": true,
+					"tags": ["import"],
+					"translations": [{"language_iso": "en", "translation": "Now"}]
+				}]}`))
+				_, _ = fmt.Fprint(w, `{"keys": [{"key_id": 3, "key_name": {"web": "empty"}}]}`)
+			case http.MethodDelete:
+				testBody(t, r, `{"keys":[5]}`)
+				_, _ = fmt.Fprint(w, `{"keys_removed": true}`)
+			}
+		})
+
+	keys := []Key{
+		{KeyName: PlatformStrings{Web: "welcome"}, Translations: []Translation{{LanguageISO: "en", Translation: "Welcome"}}},
+		{KeyName: PlatformStrings{Web: "bye"}, Translations: []Translation{{LanguageISO: "en", Translation: "Goodbye"}}},
+		{KeyName: PlatformStrings{Web: "empty"}, Translations: []Translation{{LanguageISO: "en", Translation: "Now"}}},
+		{KeyName: PlatformStrings{Web: "items"}, IsPlural: true, Translations: []Translation{{LanguageISO: "en", Translation: `{"one":"{n} item","other":"{n} items"}`}}},
+		{KeyName: PlatformStrings{Web: "hello"}, Description: "Greeting", Translations: []Translation{{LanguageISO: "en", Translation: "Hello"}}},
+		{KeyName: PlatformStrings{Web: "untranslated"}, Translations: []Translation{{LanguageISO: "de", Translation: "Nur Deutsch"}}},
+	}
+	r, err := client.Keys().Import(testProjectID, FileUpload{
+		Filename:    "en.json",
+		LangISO:     "en",
+		Tags:        []string{"import"},
+		CleanupMode: true,
+	}, keys)
+	if err != nil {
+		t.Fatalf("Keys.Import returned error: %v", err)
+	}
+
+	want := KeyImportReport{
+		Keys: []ImportedKey{
+			{KeyID: 1, KeyName: "welcome", Action: KeyImportUnchanged},
+			{KeyID: 2, KeyName: "bye", Action: KeyImportSkipped},
+			{KeyID: 3, KeyName: "empty", Action: KeyImportUpdated},
+			{KeyID: 4, KeyName: "items", Action: KeyImportUnchanged},
+			{KeyID: 10, KeyName: "hello", Action: KeyImportInserted},
+			{KeyID: 5, KeyName: "stale", Action: KeyImportDeleted},
+		},
+	}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf(assertionTemplate, "Keys.Import", r, want)
+	}
+	if got := r.Count(KeyImportUnchanged); got != 2 {
+		t.Errorf("Count(unchanged) = %d, want 2", got)
+	}
+}
+
+func TestKeyService_Import_ReplaceModified(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/keys", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch r.Method {
+			case http.MethodGet:
+				_, _ = fmt.Fprint(w, `{
+					"keys": [
+						{"key_id": 1, "key_name": {"android": "bye"}, "filenames": {"android": "strings.xml"},
+						 "translations": [{"language_iso": "de", "translation": "Tschüss"}]},
+						{"key_id": 2, "key_name": {"android": "bye"}, "filenames": {"android": "other.xml"},
+						 "translations": [{"language_iso": "de", "translation": "Tschüss"}]}
+					]
+				}`)
+			case http.MethodPut:
+				testBody(t, r, `{"keys":[{"key_id":2,"translations":[{"language_iso":"de","translation":"Auf Wiedersehen","custom_translation_status_ids":[7],"merge_custom_translation_statuses":true}]}],"use_automations":false}`)
+				_, _ = fmt.Fprint(w, `{"keys": [{"key_id": 2, "key_name": {"android": "bye"}}]}`)
+			default:
+				t.Errorf("unexpected method %s", r.Method)
+			}
+		})
+
+	keys := []Key{
+		{KeyName: PlatformStrings{Android: "bye"}, Platforms: []string{PlatformAndroid}, Translations: []Translation{{LanguageISO: "de", Translation: "Auf Wiedersehen"}}},
+	}
+	r, err := client.Keys().Import(testProjectID, FileUpload{
+		Filename:                   "other.xml",
+		LangISO:                    "de",
+		ReplaceModified:            true,
+		DistinguishByFile:          true,
+		CustomTranslationStatusIds: []int64{7},
+		UseAutomations:             Bool(false),
+	}, keys)
+	if err != nil {
+		t.Fatalf("Keys.Import returned error: %v", err)
+	}
+
+	want := KeyImportReport{Keys: []ImportedKey{{KeyID: 2, KeyName: "bye", Action: KeyImportUpdated}}}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf(assertionTemplate, "Keys.Import", r, want)
+	}
+}
+
+func TestKeyService_Import_Invalid(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	tests := []struct {
+		name string
+		file FileUpload
+		keys []Key
+	}{
+		{"no language", FileUpload{}, nil},
+		{"apply tm", FileUpload{LangISO: "en", ApplyTM: true}, nil},
+		{"cleanup without filename", FileUpload{LangISO: "en", CleanupMode: true}, nil},
+		{"mixed platforms", FileUpload{LangISO: "en"}, []Key{
+			{KeyName: PlatformStrings{Web: "a"}},
+			{KeyName: PlatformStrings{Ios: "b"}},
+		}},
+	}
+	for _, tt := range tests {
+		if _, err := client.Keys().Import(testProjectID, tt.file, tt.keys); err == nil {
+			t.Errorf("%s: Keys.Import returned no error", tt.name)
+		}
+	}
+}
+
+func TestKeyService_Import_Cleanup(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var deleteBody string
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/keys", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch r.Method {
+			case http.MethodGet:
+				if got := r.URL.Query().Get("filter_platforms"); got != PlatformWeb {
+					t.Errorf("filter_platforms = %q, want web", got)
+				}
+				_, _ = fmt.Fprint(w, `{
+					"keys": [
+						{"key_id": 1, "key_name": {"web": "title"}, "filenames": {"web": "en.json"},
+						 "translations": [{"language_iso": "en", "translation": "Title"}]},
+						{"key_id": 2, "key_name": {"web": "title"}, "filenames": {"web": "en.json"},
+						 "translations": [{"language_iso": "en", "translation": "Title"}]},
+						{"key_id": 3, "key_name": {"web": "old"}, "filenames": {"web": "en.json"}},
+						{"key_id": 4, "key_name": {"web": "other"}, "filenames": {"web": "other.json"}}
+					]
+				}`)
+			case http.MethodDelete:
+				testBody(t, r, deleteBody)
+				_, _ = fmt.Fprint(w, `{"keys_removed": true}`)
+			default:
+				t.Errorf("unexpected method %s", r.Method)
+			}
+		})
+
+	file := FileUpload{Filename: "en.json", LangISO: "en", CleanupMode: true}
+
+	// every key named title is kept
+	deleteBody = `{"keys":[3]}`
+	keys := []Key{{KeyName: PlatformStrings{Web: "title"}, Translations: []Translation{{LanguageISO: "en", Translation: "Title"}}}}
+	r, err := client.Keys().Import(testProjectID, file, keys)
+	if err != nil {
+		t.Fatalf("Keys.Import returned error: %v", err)
+	}
+	want := KeyImportReport{Keys: []ImportedKey{
+		{KeyID: 1, KeyName: "title", Action: KeyImportUnchanged},
+		{KeyID: 3, KeyName: "old", Action: KeyImportDeleted},
+	}}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf(assertionTemplate, "Keys.Import", r, want)
+	}
+
+	// an empty file deletes every key of the file, its platform coming from the filename
+	deleteBody = `{"keys":[1,2,3]}`
+	r, err = client.Keys().Import(testProjectID, file, nil)
+	if err != nil {
+		t.Fatalf("Keys.Import of an empty file returned error: %v", err)
+	}
+	if got := r.Count(KeyImportDeleted); got != 3 {
+		t.Errorf("Keys.Import of an empty file deleted %d keys, want 3", got)
+	}
+}
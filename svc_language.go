@@ -7,6 +7,8 @@ import (
 
 const (
 	pathLanguages = "languages"
+
+	maxLanguagesPageLimit = 5000
 )
 
 type LanguageService struct {
@@ -95,6 +97,20 @@ func (c *LanguageService) ListProject(projectID string) (r ListLanguagesResponse
 	return r, apiError(resp)
 }
 
+// listAll fetches every language of the project.
+func (c *LanguageService) listAll(projectID string) ([]Language, error) {
+	s := *c
+	s.Limit = maxLanguagesPageLimit
+	var languages []Language
+	err := walkOffsetPages(func(page uint) (Paged, error) {
+		s.Page = page
+		resp, err := s.ListProject(projectID)
+		languages = append(languages, resp.Languages...)
+		return resp.Paged, err
+	})
+	return languages, err
+}
+
 func (c *LanguageService) Create(projectID string, languages []NewLanguage) (r CreateLanguageResponse, err error) {
 	url := path.Join(pathProjects, projectID, pathLanguages)
 	resp, err := c.post(c.Ctx(), url, &r, map[string]interface{}{"languages": languages})
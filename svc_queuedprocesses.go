@@ -1,13 +1,40 @@
 package lokalise
 
 import (
+	"context"
 	"fmt"
+	"time"
 )
 
 const (
 	pathQueuedProcesses = "processes"
+
+	defaultProcessPollInterval = time.Second
+	maxProcessPollInterval     = 10 * time.Second
+)
+
+// Statuses of queued processes. Finished, cancelled and failed are terminal.
+const (
+	ProcessQueued         ProcessStatus = "queued"
+	ProcessPreProcessing  ProcessStatus = "pre_processing"
+	ProcessRunning        ProcessStatus = "running"
+	ProcessPostProcessing ProcessStatus = "post_processing"
+	ProcessFinished       ProcessStatus = "finished"
+	ProcessCancelled      ProcessStatus = "cancelled"
+	ProcessFailed         ProcessStatus = "failed"
 )
 
+type ProcessStatus string
+
+// IsTerminal reports whether a process with the status is over.
+func (s ProcessStatus) IsTerminal() bool {
+	switch s {
+	case ProcessFinished, ProcessCancelled, ProcessFailed:
+		return true
+	}
+	return false
+}
+
 type QueuedProcessService struct {
 	BaseService
 }
@@ -22,6 +49,20 @@ type ProcessDetails struct {
 	DownloadUrl    string `json:"download_url,omitempty"`
 	Progress       string `json:"progress,omitempty"`
 	Stage          string `json:"stage,omitempty"`
+	// Files are the files imported by file-import processes.
+	Files []ProcessFile `json:"files,omitempty"`
+}
+
+type ProcessFile struct {
+	Status           string `json:"status"`
+	Message          string `json:"message"`
+	NameOriginal     string `json:"name_original"`
+	NameCustom       string `json:"name_custom"`
+	WordCountTotal   int64  `json:"word_count_total"`
+	KeyCountTotal    int64  `json:"key_count_total"`
+	KeyCountInserted int64  `json:"key_count_inserted"`
+	KeyCountUpdated  int64  `json:"key_count_updated"`
+	KeyCountSkipped  int64  `json:"key_count_skipped"`
 }
 
 type QueuedProcess struct {
@@ -34,6 +75,9 @@ type QueuedProcess struct {
 	WithCreationTime
 }
 
+// ProcessStatus returns the status of the process, see the Process* constants.
+func (p QueuedProcess) ProcessStatus() ProcessStatus { return ProcessStatus(p.Status) }
+
 // ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
 // Service request/response objects
 // _____________________________________________________________________________________________________________________
@@ -48,6 +92,41 @@ type QueuedProcessResponse struct {
 	Process QueuedProcess `json:"process"`
 }
 
+type ProcessWaitOptions struct {
+	// PollInterval is the delay before polling the process again, doubled after every poll. Default: 1s
+	PollInterval time.Duration
+	// MaxPollInterval caps the delay between polls. Default: 10s
+	MaxPollInterval time.Duration
+	// Progress is called after every poll.
+	Progress func(p ProcessProgress)
+}
+
+// ProcessProgress is the state of a process taken from its details.
+type ProcessProgress struct {
+	ProcessID string
+	Status    ProcessStatus
+	Stage     string
+	// ItemsProcessed and ItemsToProcess are -1 when the process does not report them.
+	ItemsProcessed int
+	ItemsToProcess int
+}
+
+// ProcessError is returned when a process failed or was cancelled.
+type ProcessError struct {
+	ProcessID string
+	Type      string
+	Status    ProcessStatus
+	Message   string
+}
+
+func (e *ProcessError) Error() string {
+	process := "process"
+	if e.Type != "" {
+		process = e.Type + " process"
+	}
+	return fmt.Sprintf("lokalise: %s %s %s: %s", process, e.ProcessID, e.Status, e.Message)
+}
+
 // ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
 // Service methods
 // _____________________________________________________________________________________________________________________
@@ -70,6 +149,66 @@ func (c *QueuedProcessService) Retrieve(projectID string, processID string) (r Q
 	return r, apiError(resp)
 }
 
+// WaitForProcess polls a process until it reaches a terminal status, doubling the interval between polls.
+// A *ProcessError is returned along with the process if it failed or was cancelled.
+// Waiting stops with the context error when ctx is done.
+func (c *QueuedProcessService) WaitForProcess(ctx context.Context, projectID, processID string, opts ProcessWaitOptions) (p QueuedProcess, err error) {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultProcessPollInterval
+	}
+	maxInterval := opts.MaxPollInterval
+	if maxInterval <= 0 {
+		maxInterval = maxProcessPollInterval
+	}
+	maxInterval = max(maxInterval, interval)
+
+	s := *c
+	s.SetContext(ctx)
+	for {
+		r, err := s.Retrieve(projectID, processID)
+		if err != nil {
+			return r.Process, err
+		}
+		p = r.Process
+		if opts.Progress != nil {
+			opts.Progress(newProcessProgress(processID, p))
+		}
+		if status := p.ProcessStatus(); status.IsTerminal() {
+			if status != ProcessFinished {
+				return p, &ProcessError{ProcessID: processID, Type: p.Type, Status: status, Message: p.Message}
+			}
+			return p, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return p, ctx.Err()
+		case <-timer.C:
+		}
+		interval = min(2*interval, maxInterval)
+	}
+}
+
+func newProcessProgress(processID string, p QueuedProcess) ProcessProgress {
+	progress := ProcessProgress{
+		ProcessID:      processID,
+		Status:         p.ProcessStatus(),
+		Stage:          p.Details.Stage,
+		ItemsProcessed: -1,
+		ItemsToProcess: -1,
+	}
+	if p.Details.ItemsProcessed != nil {
+		progress.ItemsProcessed = *p.Details.ItemsProcessed
+	}
+	if p.Details.ItemsToProcess != nil {
+		progress.ItemsToProcess = *p.Details.ItemsToProcess
+	}
+	return progress
+}
+
 func pathQueuedProcessById(projectID string, processID string) string {
 	return fmt.Sprintf("%s/%s/%s/%s", pathProjects, projectID, pathQueuedProcesses, processID)
 }
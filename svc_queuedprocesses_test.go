@@ -1,10 +1,13 @@
 package lokalise
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestQueuedProcessService_List(t *testing.T) {
@@ -114,3 +117,73 @@ func TestQueuedProcessService_Retrieve(t *testing.T) {
 		t.Errorf("QueuedProcesses.Retrieve returned %+v, want %+v", r.Process, want)
 	}
 }
+
+func TestQueuedProcessService_WaitForProcess(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	polls := 0
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/processes/%s", testProjectID, "abc"),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+
+			polls++
+			switch polls {
+			case 1:
+				_, _ = fmt.Fprint(w, `{"process": {"process_id": "abc", "type": "file-import", "status": "queued"}}`)
+			case 2:
+				_, _ = fmt.Fprint(w, `{"process": {"process_id": "abc", "type": "file-import", "status": "running",
+					"details": {"stage": "importing", "items_to_process": 10, "items_processed": 4}}}`)
+			default:
+				_, _ = fmt.Fprint(w, `{"process": {"process_id": "abc", "type": "file-import", "status": "finished"}}`)
+			}
+		})
+
+	var progress []ProcessProgress
+	p, err := client.QueuedProcesses().WaitForProcess(context.Background(), testProjectID, "abc", ProcessWaitOptions{
+		PollInterval: time.Millisecond,
+		Progress:     func(p ProcessProgress) { progress = append(progress, p) },
+	})
+	if err != nil {
+		t.Fatalf("QueuedProcesses.WaitForProcess returned error: %v", err)
+	}
+	if p.ProcessStatus() != ProcessFinished || polls != 3 {
+		t.Errorf("QueuedProcesses.WaitForProcess = %s after %d polls, want finished after 3", p.Status, polls)
+	}
+
+	want := []ProcessProgress{
+		{ProcessID: "abc", Status: ProcessQueued, ItemsProcessed: -1, ItemsToProcess: -1},
+		{ProcessID: "abc", Status: ProcessRunning, Stage: "importing", ItemsProcessed: 4, ItemsToProcess: 10},
+		{ProcessID: "abc", Status: ProcessFinished, ItemsProcessed: -1, ItemsToProcess: -1},
+	}
+	if !reflect.DeepEqual(progress, want) {
+		t.Errorf(assertionTemplate, "QueuedProcesses.WaitForProcess progress", progress, want)
+	}
+}
+
+func TestQueuedProcessService_WaitForProcess_Failed(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/processes/%s", testProjectID, "abc"),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"process": {"process_id": "abc", "type": "file-import", "status": "failed", "message": "Invalid JSON"}}`)
+		})
+
+	p, err := client.QueuedProcesses().WaitForProcess(context.Background(), testProjectID, "abc", ProcessWaitOptions{})
+	var pe *ProcessError
+	if !errors.As(err, &pe) {
+		t.Fatalf("QueuedProcesses.WaitForProcess error = %v, want a *ProcessError", err)
+	}
+	want := ProcessError{ProcessID: "abc", Type: "file-import", Status: ProcessFailed, Message: "Invalid JSON"}
+	if *pe != want {
+		t.Errorf(assertionTemplate, "QueuedProcesses.WaitForProcess error", *pe, want)
+	}
+	if p.ProcessStatus() != ProcessFailed {
+		t.Errorf("QueuedProcesses.WaitForProcess status = %s, want failed", p.Status)
+	}
+}
@@ -1,8 +1,10 @@
 package lokalise
 
 import (
+	"errors"
 	"fmt"
 	"github.com/google/go-querystring/query"
+	"io"
 
 	"github.com/go-resty/resty/v2"
 )
@@ -95,6 +97,23 @@ func (c *ScreenshotService) Create(projectID string, screenshots []NewScreenshot
 	return r, apiError(resp)
 }
 
+// CreateReader creates a screenshot from content of the given MIME type, i.e. image/png, streaming it base64
+// encoded into the request body instead of holding it in memory. screenshot.Body is ignored.
+// The request is only retried if content is an io.Seeker.
+func (c *ScreenshotService) CreateReader(projectID string, content io.Reader, mime string, screenshot NewScreenshot, progress UploadProgress) (r ScreenshotsResponse, err error) {
+	if mime == "" {
+		return r, errors.New("lokalise: screenshot MIME type is required")
+	}
+	screenshot.Body = "data:" + mime + ";base64," + streamDataToken
+	err = c.postStream(c.Ctx(), fmt.Sprintf("%s/%s/%s", pathProjects, projectID, pathScreenshots), &r,
+		map[string]interface{}{
+			"screenshots": []NewScreenshot{screenshot},
+		},
+		content, progress,
+	)
+	return
+}
+
 func (c *ScreenshotService) Retrieve(projectID string, screenshotID int64) (r ScreenshotResponse, err error) {
 	resp, err := c.get(c.Ctx(), fmt.Sprintf("%s/%s/%s/%d", pathProjects, projectID, pathScreenshots, screenshotID), &r)
 
@@ -1,7 +1,9 @@
 package lokalise
 
 import (
+	"encoding/json"
 	"fmt"
+
 	"github.com/go-resty/resty/v2"
 	"github.com/google/go-querystring/query"
 )
@@ -53,6 +55,28 @@ type SegmentUpdateRequest struct {
 	CustomTranslationStatusIds []int64 `json:"custom_translation_status_ids,omitempty"`
 }
 
+func (r SegmentUpdateRequest) MarshalJSON() ([]byte, error) {
+	type Alias SegmentUpdateRequest
+
+	var value interface{} = r.Value
+
+	if json.Valid([]byte(r.Value)) {
+		var unmarshalled map[string]interface{}
+
+		if err := json.Unmarshal([]byte(r.Value), &unmarshalled); err == nil {
+			value = unmarshalled
+		}
+	}
+
+	return json.Marshal(&struct {
+		Value interface{} `json:"value"`
+		Alias
+	}{
+		Value: value,
+		Alias: (Alias)(r),
+	})
+}
+
 func (s *SegmentationService) List(projectID string, keyID int64, languageIso string) (r SegmentsResponse, err error) {
 	resp, err := s.getWithOptions(
 		s.Ctx(),
@@ -7,6 +7,8 @@ import (
 
 const (
 	pathSnapshots = "snapshots"
+
+	maxSnapshotsPageLimit = 5000
 )
 
 type SnapshotService struct {
@@ -10,6 +10,8 @@ import (
 
 const (
 	pathTranslations = "translations"
+
+	maxTranslationsPageLimit = 5000
 )
 
 // TranslationService supports List, Retrieve and Update commands
@@ -26,7 +28,7 @@ type TranslationService struct {
 
 type Translation struct {
 	TranslationID   int64  `json:"translation_id"`
-	Translation     string `json:"translation"` // could be string or json in case it includes plural forms and is_plural is true.
+	Translation     string `json:"translation"` // could be string or json in case it includes plural forms and is_plural is true, see Plural.
 	KeyID           int64  `json:"key_id"`
 	LanguageISO     string `json:"language_iso"`
 	ModifiedAt      string `json:"modified_at"`
@@ -222,6 +224,31 @@ func (c *TranslationService) Update(projectID string, translationID int64, opts
 	return r, apiError(resp)
 }
 
+// listAll fetches every translation matching opts, following the cursor pagination.
+func (c *TranslationService) listAll(projectID string, opts TranslationListOptions) ([]Translation, error) {
+	opts.Pagination = PaginationCursor
+	opts.Page = 0
+	opts.Cursor = ""
+	if opts.Limit == 0 {
+		opts.Limit = maxTranslationsPageLimit
+	}
+
+	s := *c
+	var translations []Translation
+	for {
+		s.opts = opts
+		r, err := s.List(projectID)
+		if err != nil {
+			return nil, err
+		}
+		translations = append(translations, r.Translations...)
+		if !r.HasNextCursor() {
+			return translations, nil
+		}
+		opts.Cursor = r.NextCursor()
+	}
+}
+
 // ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
 // Additional methods
 // _____________________________________________________________________________________________________________________
@@ -0,0 +1,99 @@
+package lokalise
+
+import (
+	"fmt"
+	"sync"
+)
+
+// defaultBulkWorkers is the number of concurrent requests of the bulk helpers. The API allows a single
+// concurrent request per token, so more workers need WithRateLimit on the client to stay within the API
+// rate limits, and WithRetryOnRateLimit to retry the requests rejected anyway.
+const defaultBulkWorkers = 1
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service request/response objects
+// _____________________________________________________________________________________________________________________
+
+type TranslationUpdate struct {
+	TranslationID int64
+	Update        UpdateTranslation
+}
+
+type TranslationUpdateResult struct {
+	TranslationID int64
+	// Translation is the updated translation returned by the API.
+	Translation Translation
+	Err         error
+}
+
+type BulkTranslationUpdateOptions struct {
+	// Workers is the number of concurrent requests, see defaultBulkWorkers. Default: 1
+	Workers int
+	// Progress is called after every update with the number of finished and total updates.
+	// Calls are serialized.
+	Progress func(done, total int)
+}
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service methods
+// _____________________________________________________________________________________________________________________
+
+// BulkUpdate updates many translations concurrently, one request per translation.
+// Results are returned in the order of updates. The error reports the number of failed updates,
+// the failures themselves are set on the results. Once the service context is done,
+// the remaining updates fail with the context error.
+func (c *TranslationService) BulkUpdate(projectID string, updates []TranslationUpdate, opts BulkTranslationUpdateOptions) (r []TranslationUpdateResult, err error) {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = defaultBulkWorkers
+	}
+	workers = min(workers, len(updates))
+
+	r = make([]TranslationUpdateResult, len(updates))
+	jobs := make(chan int)
+
+	var (
+		mu     sync.Mutex
+		done   int
+		failed int
+		wg     sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				u := updates[i]
+				res := TranslationUpdateResult{TranslationID: u.TranslationID}
+				if ctxErr := c.Ctx().Err(); ctxErr != nil {
+					res.Err = ctxErr
+				} else {
+					resp, err := c.Update(projectID, u.TranslationID, u.Update)
+					res.Translation, res.Err = resp.Translation, err
+				}
+				r[i] = res
+
+				mu.Lock()
+				done++
+				if res.Err != nil {
+					failed++
+				}
+				if opts.Progress != nil {
+					opts.Progress(done, len(updates))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := range updates {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if failed > 0 {
+		return r, fmt.Errorf("lokalise: %d of %d translation updates failed", failed, len(updates))
+	}
+	return r, nil
+}
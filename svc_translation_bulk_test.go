@@ -0,0 +1,88 @@
+package lokalise
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestTranslationService_BulkUpdate(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/translations/", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "PUT")
+			testBody(t, r, `{"translation":"Hi","is_reviewed":true}`)
+
+			id := strings.TrimPrefix(r.URL.Path, fmt.Sprintf("/projects/%s/translations/", testProjectID))
+			mu.Lock()
+			requests = append(requests, id)
+			mu.Unlock()
+
+			if id == "3" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = fmt.Fprint(w, `{"error": {"code": 404, "message": "Translation not found"}}`)
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{
+				"project_id": "%s",
+				"translation": {"translation_id": %s, "translation": "Hi", "is_reviewed": true}
+			}`, testProjectID, id)
+		})
+
+	update := UpdateTranslation{Translation: "Hi", IsReviewed: true}
+	updates := []TranslationUpdate{
+		{TranslationID: 1, Update: update},
+		{TranslationID: 2, Update: update},
+		{TranslationID: 3, Update: update},
+		{TranslationID: 4, Update: update},
+	}
+
+	var progress []int
+	r, err := client.Translations().BulkUpdate(testProjectID, updates, BulkTranslationUpdateOptions{
+		Workers: 2,
+		Progress: func(done, total int) {
+			if total != 4 {
+				t.Errorf("Progress total = %d, want 4", total)
+			}
+			progress = append(progress, done)
+		},
+	})
+	if err == nil || err.Error() != "lokalise: 1 of 4 translation updates failed" {
+		t.Errorf("Translations.BulkUpdate returned error: %v", err)
+	}
+	if len(requests) != 4 {
+		t.Errorf("Translations.BulkUpdate sent %d requests, want 4", len(requests))
+	}
+	if !reflect.DeepEqual(progress, []int{1, 2, 3, 4}) {
+		t.Errorf("Progress was called with %v", progress)
+	}
+
+	for i, res := range r {
+		id := updates[i].TranslationID
+		if res.TranslationID != id {
+			t.Errorf("result %d has translation ID %d, want %d", i, res.TranslationID, id)
+		}
+		if id == 3 {
+			want := Error{Code: 404, Message: "Translation not found"}
+			if !reflect.DeepEqual(res.Err, want) {
+				t.Errorf("result %d has error %v, want %v", i, res.Err, want)
+			}
+			continue
+		}
+		want := Translation{TranslationID: id, Translation: "Hi", IsReviewed: true}
+		if res.Err != nil || !reflect.DeepEqual(res.Translation, want) {
+			t.Errorf(assertionTemplate, "Translations.BulkUpdate", res, want)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package lokalise
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service request/response objects
+// _____________________________________________________________________________________________________________________
+
+// TranslationsByKey groups translations by key ID and language ISO code.
+type TranslationsByKey map[int64]map[string]Translation
+
+type MultiLanguageListOptions struct {
+	// Filter narrows down the translations of every language, its FilterLangID and pagination are ignored.
+	Filter TranslationListOptions
+	// Workers is the number of languages fetched concurrently, see defaultBulkWorkers. Default: 1
+	Workers int
+}
+
+// ‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Service methods
+// _____________________________________________________________________________________________________________________
+
+// ListByLanguages fetches every translation of the given languages, walking the pages of each language
+// concurrently. Languages are resolved from their ISO codes through the project languages.
+// The first failure cancels the remaining walks and is returned.
+func (c *TranslationService) ListByLanguages(projectID string, langISOs []string, opts MultiLanguageListOptions) (r TranslationsByKey, err error) {
+	ls := LanguageService{c.BaseService}
+	langs, err := ls.listAll(projectID)
+	if err != nil {
+		return
+	}
+	langIDs := make(map[string]int64, len(langs))
+	for _, l := range langs {
+		langIDs[l.LangISO] = l.LangID
+	}
+	for _, iso := range langISOs {
+		if _, ok := langIDs[iso]; !ok {
+			return nil, fmt.Errorf("lokalise: language %s is not a project language", iso)
+		}
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = defaultBulkWorkers
+	}
+	workers = min(workers, len(langISOs))
+
+	ctx, cancel := context.WithCancel(c.Ctx())
+	defer cancel()
+	s := *c
+	s.SetContext(ctx)
+
+	r = make(TranslationsByKey)
+	jobs := make(chan string)
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for iso := range jobs {
+				filter := opts.Filter
+				filter.FilterLangID = langIDs[iso]
+				translations, err := s.listAll(projectID, filter)
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = fmt.Errorf("lokalise: list %s translations: %w", iso, err)
+						cancel()
+					}
+				} else {
+					for _, t := range translations {
+						if r[t.KeyID] == nil {
+							r[t.KeyID] = make(map[string]Translation)
+						}
+						r[t.KeyID][iso] = t
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, iso := range langISOs {
+		jobs <- iso
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return r, nil
+}
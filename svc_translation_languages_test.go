@@ -0,0 +1,97 @@
+package lokalise
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestTranslationService_ListByLanguages(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/languages", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			_, _ = fmt.Fprint(w, `{"languages": [
+				{"lang_id": 1, "lang_iso": "en"},
+				{"lang_id": 2, "lang_iso": "de"},
+				{"lang_id": 3, "lang_iso": "fr"}
+			]}`)
+		})
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/translations", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			q := r.URL.Query()
+			if q.Get("pagination") != "cursor" || q.Get("limit") != "5000" || q.Get("filter_is_reviewed") != "1" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			switch q.Get("filter_lang_id") + "/" + q.Get("cursor") {
+			case "1/":
+				w.Header().Set(headerNextCursor, "next")
+				_, _ = fmt.Fprint(w, `{"translations": [{"translation_id": 11, "key_id": 1, "language_iso": "en", "translation": "Yes"}]}`)
+			case "1/next":
+				_, _ = fmt.Fprint(w, `{"translations": [{"translation_id": 12, "key_id": 2, "language_iso": "en", "translation": "No"}]}`)
+			case "2/":
+				_, _ = fmt.Fprint(w, `{"translations": [{"translation_id": 21, "key_id": 1, "language_iso": "de", "translation": "Ja"}]}`)
+			default:
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+		})
+
+	r, err := client.Translations().ListByLanguages(testProjectID, []string{"en", "de"}, MultiLanguageListOptions{
+		Filter: TranslationListOptions{FilterIsReviewed: 1, FilterLangID: 3, Page: 2},
+	})
+	if err != nil {
+		t.Fatalf("Translations.ListByLanguages returned error: %v", err)
+	}
+
+	want := TranslationsByKey{
+		1: {
+			"en": {TranslationID: 11, KeyID: 1, LanguageISO: "en", Translation: "Yes"},
+			"de": {TranslationID: 21, KeyID: 1, LanguageISO: "de", Translation: "Ja"},
+		},
+		2: {
+			"en": {TranslationID: 12, KeyID: 2, LanguageISO: "en", Translation: "No"},
+		},
+	}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf(assertionTemplate, "Translations.ListByLanguages", r, want)
+	}
+}
+
+func TestTranslationService_ListByLanguages_Errors(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/languages", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"languages": [{"lang_id": 1, "lang_iso": "en"}, {"lang_id": 2, "lang_iso": "de"}]}`)
+		})
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/translations", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("filter_lang_id") == "2" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = fmt.Fprint(w, `{"error": {"code": 400, "message": "Invalid filter"}}`)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"translations": []}`)
+		})
+
+	if _, err := client.Translations().ListByLanguages(testProjectID, []string{"en", "it"}, MultiLanguageListOptions{}); err == nil {
+		t.Error("ListByLanguages with an unknown language returned no error")
+	}
+	if _, err := client.Translations().ListByLanguages(testProjectID, []string{"en", "de"}, MultiLanguageListOptions{}); err == nil {
+		t.Error("ListByLanguages with a failing language returned no error")
+	}
+}
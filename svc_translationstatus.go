@@ -6,6 +6,8 @@ import (
 
 const (
 	pathTranslationStatuses = "custom_translation_statuses"
+
+	maxTranslationStatusesPageLimit = 5000
 )
 
 type TranslationStatusService struct {
@@ -0,0 +1,324 @@
+package lokalise
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+const (
+	defaultTMMinScore = 0.75
+	defaultTMLimit    = 10
+
+	tmFileVersion = 1
+)
+
+// TMEntry is a pair of base and target translations of a key.
+type TMEntry struct {
+	WithProjectID
+	KeyID        int64  `json:"key_id"`
+	SourceLang   string `json:"source_lang"`
+	TargetLang   string `json:"target_lang"`
+	Source       string `json:"source"`
+	Target       string `json:"target"`
+	ModifiedAtTs int64  `json:"modified_at_timestamp"`
+}
+
+type TMMatch struct {
+	TMEntry
+	// Score is the similarity of the sources between 0 and 1.
+	Score float64
+	// Exact is set when the sources are identical, including case and whitespace.
+	Exact bool
+}
+
+type TMLookupOptions struct {
+	// MinScore is the lowest similarity of returned matches. Default: 0.75
+	MinScore float64
+	// Limit is the maximal number of matches. Default: 10
+	Limit int
+}
+
+// TMUpdateResult counts the changes made by KeyService.UpdateTranslationMemory.
+type TMUpdateResult struct {
+	Indexed int
+	Removed int
+}
+
+// TranslationMemory is a local index of translation pairs with exact and fuzzy lookups.
+// It is safe for concurrent use.
+type TranslationMemory struct {
+	mu         sync.RWMutex
+	entries    map[tmEntryID]TMEntry
+	watermarks map[string]int64
+}
+
+type tmEntryID struct {
+	projectID  string
+	keyID      int64
+	sourceLang string
+	targetLang string
+}
+
+func (e TMEntry) id() tmEntryID {
+	return tmEntryID{e.ProjectID, e.KeyID, e.SourceLang, e.TargetLang}
+}
+
+type tmFile struct {
+	Version    int              `json:"version"`
+	Watermarks map[string]int64 `json:"watermarks"`
+	Entries    []TMEntry        `json:"entries"`
+}
+
+func NewTranslationMemory() *TranslationMemory {
+	return &TranslationMemory{
+		entries:    make(map[tmEntryID]TMEntry),
+		watermarks: make(map[string]int64),
+	}
+}
+
+// Add indexes a pair, replacing the pair of the same project, key and languages.
+func (tm *TranslationMemory) Add(e TMEntry) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	tm.entries[e.id()] = e
+	if e.ModifiedAtTs > tm.watermarks[e.ProjectID] {
+		tm.watermarks[e.ProjectID] = e.ModifiedAtTs
+	}
+}
+
+func (tm *TranslationMemory) Len() int {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	return len(tm.entries)
+}
+
+// Watermark returns the latest modification time indexed for the project.
+func (tm *TranslationMemory) Watermark(projectID string) int64 {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	return tm.watermarks[projectID]
+}
+
+// IndexKeys indexes the translations of keys listed with their translations, paired with the
+// sourceLang translation. Only pairs not indexed yet or whose texts changed are (re)indexed, as
+// modification times only have a precision of a second. Pairs of keys or languages absent from keys,
+// or paired with another source language, are removed from the memory, so keys must hold the whole
+// project. Plural keys are not indexed.
+func (tm *TranslationMemory) IndexKeys(projectID, sourceLang string, keys []Key) (r TMUpdateResult) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	seen := make(map[tmEntryID]bool)
+
+	for _, k := range keys {
+		if k.IsPlural {
+			continue
+		}
+		var source Translation
+		for _, t := range k.Translations {
+			if t.LanguageISO == sourceLang {
+				source = t
+			}
+		}
+		if source.Translation == "" {
+			continue
+		}
+
+		for _, t := range k.Translations {
+			if t.LanguageISO == sourceLang || t.Translation == "" {
+				continue
+			}
+			id := tmEntryID{projectID, k.KeyID, sourceLang, t.LanguageISO}
+			seen[id] = true
+
+			modified := max(source.ModifiedAtTs, t.ModifiedAtTs)
+			if e, ok := tm.entries[id]; ok && e.Source == source.Translation && e.Target == t.Translation {
+				continue
+			}
+			tm.entries[id] = TMEntry{
+				WithProjectID: WithProjectID{ProjectID: projectID},
+				KeyID:         k.KeyID,
+				SourceLang:    sourceLang,
+				TargetLang:    t.LanguageISO,
+				Source:        source.Translation,
+				Target:        t.Translation,
+				ModifiedAtTs:  modified,
+			}
+			r.Indexed++
+			if modified > tm.watermarks[projectID] {
+				tm.watermarks[projectID] = modified
+			}
+		}
+	}
+
+	for id := range tm.entries {
+		if id.projectID == projectID && !seen[id] {
+			delete(tm.entries, id)
+			r.Removed++
+		}
+	}
+	return r
+}
+
+// Lookup returns the best matches of a source string for the language pair, best scores first.
+func (tm *TranslationMemory) Lookup(source, sourceLang, targetLang string, opts TMLookupOptions) []TMMatch {
+	if opts.MinScore <= 0 {
+		opts.MinScore = defaultTMMinScore
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = defaultTMLimit
+	}
+
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	normalized := normalizeText(source)
+	var matches []TMMatch
+	for _, e := range tm.entries {
+		if e.SourceLang != sourceLang || e.TargetLang != targetLang {
+			continue
+		}
+		candidate := normalizeText(e.Source)
+		if maxSimilarity(normalized, candidate) < opts.MinScore {
+			continue
+		}
+		score := similarity(normalized, candidate)
+		if score < opts.MinScore {
+			continue
+		}
+		matches = append(matches, TMMatch{TMEntry: e, Score: score, Exact: e.Source == source})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Exact != b.Exact {
+			return a.Exact
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.ModifiedAtTs != b.ModifiedAtTs {
+			return a.ModifiedAtTs > b.ModifiedAtTs
+		}
+		if a.ProjectID != b.ProjectID {
+			return a.ProjectID < b.ProjectID
+		}
+		return a.KeyID < b.KeyID
+	})
+	if len(matches) > opts.Limit {
+		matches = matches[:opts.Limit]
+	}
+	return matches
+}
+
+// Save writes the memory as JSON.
+func (tm *TranslationMemory) Save(w io.Writer) error {
+	tm.mu.RLock()
+	f := tmFile{
+		Version:    tmFileVersion,
+		Watermarks: make(map[string]int64, len(tm.watermarks)),
+		Entries:    make([]TMEntry, 0, len(tm.entries)),
+	}
+	for projectID, ts := range tm.watermarks {
+		f.Watermarks[projectID] = ts
+	}
+	for _, e := range tm.entries {
+		f.Entries = append(f.Entries, e)
+	}
+	tm.mu.RUnlock()
+
+	sort.Slice(f.Entries, func(i, j int) bool {
+		a, b := f.Entries[i], f.Entries[j]
+		if a.ProjectID != b.ProjectID {
+			return a.ProjectID < b.ProjectID
+		}
+		if a.KeyID != b.KeyID {
+			return a.KeyID < b.KeyID
+		}
+		if a.SourceLang != b.SourceLang {
+			return a.SourceLang < b.SourceLang
+		}
+		return a.TargetLang < b.TargetLang
+	})
+	return json.NewEncoder(w).Encode(f)
+}
+
+// SaveFile writes the memory to a file, replacing it atomically.
+func (tm *TranslationMemory) SaveFile(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tm-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tm.Save(tmp); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadTranslationMemory reads a memory written by Save.
+func LoadTranslationMemory(r io.Reader) (*TranslationMemory, error) {
+	var f tmFile
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, err
+	}
+	if f.Version != tmFileVersion {
+		return nil, fmt.Errorf("lokalise: unsupported translation memory version %d", f.Version)
+	}
+
+	tm := NewTranslationMemory()
+	for _, e := range f.Entries {
+		tm.entries[e.id()] = e
+	}
+	for projectID, ts := range f.Watermarks {
+		tm.watermarks[projectID] = ts
+	}
+	return tm, nil
+}
+
+// LoadTranslationMemoryFile reads a memory written by SaveFile. An empty memory is returned
+// if the file does not exist yet.
+func LoadTranslationMemoryFile(path string) (*TranslationMemory, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewTranslationMemory(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadTranslationMemory(f)
+}
+
+// UpdateTranslationMemory indexes the translations of a project, paired with its base language.
+// The update is not incremental on the network side: the Keys API cannot filter by modification time,
+// and the whole project is needed to remove the deleted pairs, so every key is listed with its translations
+// on each run. Only the changed pairs are re-indexed locally, see TranslationMemory.IndexKeys.
+func (c *KeyService) UpdateTranslationMemory(tm *TranslationMemory, projectID string) (r TMUpdateResult, err error) {
+	ps := ProjectService{BaseService: c.BaseService}
+	project, err := ps.Retrieve(projectID)
+	if err != nil {
+		return
+	}
+
+	keys, err := c.listAll(projectID, KeyListOptions{IncludeTranslations: 1})
+	if err != nil {
+		return
+	}
+	return tm.IndexKeys(projectID, project.BaseLangISO, keys), nil
+}
@@ -0,0 +1,196 @@
+package lokalise
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestKeyService_UpdateTranslationMemory(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			_, _ = fmt.Fprint(w, `{"project_id": "`+testProjectID+`", "base_language_iso": "en"}`)
+		})
+
+	fr := "Enregistrer"
+	mux.HandleFunc(
+		fmt.Sprintf("/projects/%s/keys", testProjectID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			testMethod(t, r, "GET")
+			_, _ = fmt.Fprint(w, `{
+				"keys": [
+					{
+						"key_id": 1,
+						"translations": [
+							{"language_iso": "en", "translation": "Save", "modified_at_timestamp": 100},
+							{"language_iso": "fr", "translation": "`+fr+`", "modified_at_timestamp": 200},
+							{"language_iso": "de", "translation": ""}
+						]
+					},
+					{
+						"key_id": 2,
+						"is_plural": true,
+						"translations": [
+							{"language_iso": "en", "translation": "{\"one\":\"1 file\",\"other\":\"{n} files\"}"},
+							{"language_iso": "fr", "translation": "{\"one\":\"1 fichier\",\"other\":\"{n} fichiers\"}"}
+						]
+					}
+				]
+			}`)
+		})
+
+	tm := NewTranslationMemory()
+	r, err := client.Keys().UpdateTranslationMemory(tm, testProjectID)
+	if err != nil {
+		t.Fatalf("Keys.UpdateTranslationMemory returned error: %v", err)
+	}
+	if want := (TMUpdateResult{Indexed: 1}); r != want {
+		t.Errorf(assertionTemplate, "UpdateTranslationMemory", r, want)
+	}
+	if got := tm.Watermark(testProjectID); got != 200 {
+		t.Errorf(assertionTemplate, "Watermark", got, 200)
+	}
+
+	// unchanged pairs are kept as is
+	r, err = client.Keys().UpdateTranslationMemory(tm, testProjectID)
+	if err != nil {
+		t.Fatalf("Keys.UpdateTranslationMemory returned error: %v", err)
+	}
+	if want := (TMUpdateResult{}); r != want {
+		t.Errorf(assertionTemplate, "UpdateTranslationMemory", r, want)
+	}
+
+	matches := tm.Lookup("Save", "en", "fr", TMLookupOptions{})
+	if len(matches) != 1 || matches[0].Target != fr || !matches[0].Exact {
+		t.Errorf(assertionTemplate, "Lookup", matches, fr)
+	}
+}
+
+func TestTranslationMemory_IndexKeys(t *testing.T) {
+	tm := NewTranslationMemory()
+	key := func(id, ts int64, en, fr string) Key {
+		return Key{KeyID: id, Translations: []Translation{
+			{LanguageISO: "en", Translation: en, ModifiedAtTs: ts},
+			{LanguageISO: "fr", Translation: fr, ModifiedAtTs: ts},
+		}}
+	}
+
+	tm.IndexKeys("p1", "en", []Key{key(1, 10, "Save", "Enregistrer"), key(2, 10, "Cancel", "Annuler")})
+	r := tm.IndexKeys("p1", "en", []Key{key(1, 20, "Save file", "Enregistrer le fichier")})
+	if want := (TMUpdateResult{Indexed: 1, Removed: 1}); r != want {
+		t.Errorf(assertionTemplate, "IndexKeys", r, want)
+	}
+	tm.IndexKeys("p2", "en", []Key{key(1, 5, "Cancel", "Annuler")})
+
+	if got := tm.Len(); got != 2 {
+		t.Errorf(assertionTemplate, "Len", got, 2)
+	}
+	if got := tm.Lookup("Save", "en", "fr", TMLookupOptions{}); len(got) != 0 {
+		t.Errorf(assertionTemplate, "Lookup replaced source", got, nil)
+	}
+	got := tm.Lookup("Cancel", "en", "fr", TMLookupOptions{})
+	if len(got) != 1 || got[0].ProjectID != "p2" {
+		t.Errorf(assertionTemplate, "Lookup across projects", got, "p2")
+	}
+}
+
+func TestTranslationMemory_IndexKeys_Changes(t *testing.T) {
+	tm := NewTranslationMemory()
+	key := Key{KeyID: 1, Translations: []Translation{
+		{LanguageISO: "en", Translation: "Save", ModifiedAtTs: 10},
+		{LanguageISO: "fr", Translation: "Enregistrer", ModifiedAtTs: 10},
+	}}
+	tm.IndexKeys("p", "en", []Key{key})
+
+	// edited within the second of the watermark
+	key.Translations[1].Translation = "Sauvegarder"
+	if r := tm.IndexKeys("p", "en", []Key{key}); r != (TMUpdateResult{Indexed: 1}) {
+		t.Errorf(assertionTemplate, "IndexKeys same second", r, TMUpdateResult{Indexed: 1})
+	}
+	if r := tm.IndexKeys("p", "en", []Key{key}); r != (TMUpdateResult{}) {
+		t.Errorf(assertionTemplate, "IndexKeys unchanged", r, TMUpdateResult{})
+	}
+	if got := tm.Lookup("Save", "en", "fr", TMLookupOptions{}); len(got) != 1 || got[0].Target != "Sauvegarder" {
+		t.Errorf(assertionTemplate, "Lookup edited target", got, "Sauvegarder")
+	}
+
+	// paired with another source language
+	if r := tm.IndexKeys("p", "fr", []Key{key}); r != (TMUpdateResult{Indexed: 1, Removed: 1}) {
+		t.Errorf(assertionTemplate, "IndexKeys source language", r, TMUpdateResult{Indexed: 1, Removed: 1})
+	}
+	if got := tm.Lookup("Save", "en", "fr", TMLookupOptions{}); len(got) != 0 {
+		t.Errorf(assertionTemplate, "Lookup previous source language", got, nil)
+	}
+	if got := tm.Lookup("Sauvegarder", "fr", "en", TMLookupOptions{}); len(got) != 1 {
+		t.Errorf(assertionTemplate, "Lookup new source language", len(got), 1)
+	}
+}
+
+func TestTranslationMemory_Lookup(t *testing.T) {
+	tm := NewTranslationMemory()
+	add := func(id int64, source, target string) {
+		tm.Add(TMEntry{WithProjectID: WithProjectID{ProjectID: "p"}, KeyID: id, SourceLang: "en", TargetLang: "de", Source: source, Target: target})
+	}
+	add(1, "Delete the file", "Datei löschen")
+	add(2, "delete the file", "die Datei löschen")
+	add(3, "Delete the files", "Dateien löschen")
+	add(4, "Open settings", "Einstellungen öffnen")
+
+	got := tm.Lookup("Delete the file", "en", "de", TMLookupOptions{})
+	var ids []int64
+	for _, m := range got {
+		ids = append(ids, m.KeyID)
+	}
+	if want := []int64{1, 2, 3}; !reflect.DeepEqual(ids, want) {
+		t.Errorf(assertionTemplate, "Lookup order", ids, want)
+	}
+	if !got[0].Exact || got[1].Exact || got[1].Score != 1 || got[2].Score >= 1 {
+		t.Errorf(assertionTemplate, "Lookup scores", got, "exact, normalized, fuzzy")
+	}
+
+	if got := tm.Lookup("Delete the file", "en", "de", TMLookupOptions{Limit: 1}); len(got) != 1 {
+		t.Errorf(assertionTemplate, "Lookup limit", len(got), 1)
+	}
+	if got := tm.Lookup("Delete the file", "en", "fr", TMLookupOptions{}); len(got) != 0 {
+		t.Errorf(assertionTemplate, "Lookup other language", got, nil)
+	}
+}
+
+func TestTranslationMemory_SaveLoad(t *testing.T) {
+	tm := NewTranslationMemory()
+	tm.Add(TMEntry{WithProjectID: WithProjectID{ProjectID: "p"}, KeyID: 1, SourceLang: "en", TargetLang: "de", Source: "Yes", Target: "Ja", ModifiedAtTs: 42})
+
+	var buf bytes.Buffer
+	if err := tm.Save(&buf); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	loaded, err := LoadTranslationMemory(&buf)
+	if err != nil {
+		t.Fatalf("LoadTranslationMemory returned error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.entries, tm.entries) || loaded.Watermark("p") != 42 {
+		t.Errorf(assertionTemplate, "LoadTranslationMemory", loaded.entries, tm.entries)
+	}
+
+	path := filepath.Join(t.TempDir(), "tm.json")
+	empty, err := LoadTranslationMemoryFile(path)
+	if err != nil || empty.Len() != 0 {
+		t.Errorf("LoadTranslationMemoryFile of a missing file = %v, %v", empty, err)
+	}
+	if err := tm.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile returned error: %v", err)
+	}
+	if loaded, err = LoadTranslationMemoryFile(path); err != nil || loaded.Len() != 1 {
+		t.Errorf("LoadTranslationMemoryFile = %v, %v", loaded, err)
+	}
+}
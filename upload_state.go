@@ -0,0 +1,181 @@
+package lokalise
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const uploadStateFileVersion = 1
+
+// UploadStateKey identifies an uploaded file. Branch is empty for the main branch.
+type UploadStateKey struct {
+	ProjectID string `json:"project_id"`
+	Branch    string `json:"branch,omitempty"`
+	Filename  string `json:"filename"`
+	LangISO   string `json:"lang_iso"`
+}
+
+// NewUploadStateKey builds the key of a file uploaded to projectID,
+// which may carry a branch as "projectID:branch".
+func NewUploadStateKey(projectID, filename, langISO string) UploadStateKey {
+	project, branch, _ := strings.Cut(projectID, ":")
+	return UploadStateKey{ProjectID: project, Branch: branch, Filename: filename, LangISO: langISO}
+}
+
+// UploadStateStore records the content hashes of the last successful uploads,
+// so that unchanged files are not uploaded again. Implementations must be safe for concurrent use.
+type UploadStateStore interface {
+	// Get returns the hash of the last successful upload, ok is false if the file was never uploaded.
+	Get(key UploadStateKey) (hash string, ok bool, err error)
+	// Put records the hash of a successful upload.
+	Put(key UploadStateKey, hash string) error
+}
+
+// UploadContentHash returns the hash of an uploaded content, as recorded in an UploadStateStore.
+func UploadContentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// uploadReaderHash returns the UploadContentHash of the content read from r.
+func uploadReaderHash(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// MemoryUploadState is an UploadStateStore kept in memory, i.e. for a single run or tests.
+type MemoryUploadState struct {
+	mu     sync.RWMutex
+	hashes map[UploadStateKey]string
+}
+
+func NewMemoryUploadState() *MemoryUploadState {
+	return &MemoryUploadState{hashes: make(map[UploadStateKey]string)}
+}
+
+func (s *MemoryUploadState) Get(key UploadStateKey) (string, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	hash, ok := s.hashes[key]
+	return hash, ok, nil
+}
+
+func (s *MemoryUploadState) Put(key UploadStateKey, hash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hashes[key] = hash
+	return nil
+}
+
+// FileUploadState is an UploadStateStore persisted as JSON, i.e. in a CI cache.
+// The file is rewritten after every Put, so that an interrupted push keeps its progress.
+type FileUploadState struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[UploadStateKey]uploadStateEntry
+}
+
+type uploadStateEntry struct {
+	UploadStateKey
+	Hash       string    `json:"hash"`
+	UploadedAt time.Time `json:"uploaded_at"`
+}
+
+type uploadStateFile struct {
+	Version int                `json:"version"`
+	Entries []uploadStateEntry `json:"entries"`
+}
+
+// OpenFileUploadState loads the state stored at path, which is created by the first Put if it does not exist.
+func OpenFileUploadState(path string) (*FileUploadState, error) {
+	s := &FileUploadState{path: path, entries: make(map[UploadStateKey]uploadStateEntry)}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f uploadStateFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("lokalise: read upload state %s: %w", path, err)
+	}
+	if f.Version != uploadStateFileVersion {
+		return nil, fmt.Errorf("lokalise: unsupported upload state version %d", f.Version)
+	}
+	for _, e := range f.Entries {
+		s.entries[e.UploadStateKey] = e
+	}
+	return s, nil
+}
+
+func (s *FileUploadState) Get(key UploadStateKey) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	return e.Hash, ok, nil
+}
+
+func (s *FileUploadState) Put(key UploadStateKey, hash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = uploadStateEntry{UploadStateKey: key, Hash: hash, UploadedAt: time.Now().UTC()}
+	return s.save()
+}
+
+// save writes the entries through a temporary file, the lock must be held.
+func (s *FileUploadState) save() error {
+	f := uploadStateFile{Version: uploadStateFileVersion, Entries: make([]uploadStateEntry, 0, len(s.entries))}
+	for _, e := range s.entries {
+		f.Entries = append(f.Entries, e)
+	}
+	sort.Slice(f.Entries, func(i, j int) bool {
+		a, b := f.Entries[i].UploadStateKey, f.Entries[j].UploadStateKey
+		if a.ProjectID != b.ProjectID {
+			return a.ProjectID < b.ProjectID
+		}
+		if a.Branch != b.Branch {
+			return a.Branch < b.Branch
+		}
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.LangISO < b.LangISO
+	})
+
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".upload-state-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
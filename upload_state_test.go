@@ -0,0 +1,52 @@
+package lokalise
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewUploadStateKey(t *testing.T) {
+	got := NewUploadStateKey(testProjectID+":feature", "en.json", "en")
+	want := UploadStateKey{ProjectID: testProjectID, Branch: "feature", Filename: "en.json", LangISO: "en"}
+	if got != want {
+		t.Errorf(assertionTemplate, "NewUploadStateKey", got, want)
+	}
+	if got := NewUploadStateKey(testProjectID, "en.json", "en"); got.Branch != "" {
+		t.Errorf(assertionTemplate, "NewUploadStateKey branch", got.Branch, "")
+	}
+}
+
+func TestFileUploadState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	key := NewUploadStateKey(testProjectID, "%LANG_ISO%.json", "en")
+
+	s, err := OpenFileUploadState(path)
+	if err != nil {
+		t.Fatalf("OpenFileUploadState returned error: %v", err)
+	}
+	if _, ok, _ := s.Get(key); ok {
+		t.Error("empty state has a hash")
+	}
+	if err := s.Put(key, UploadContentHash([]byte("a"))); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+
+	s, err = OpenFileUploadState(path)
+	if err != nil {
+		t.Fatalf("OpenFileUploadState returned error: %v", err)
+	}
+	if hash, ok, _ := s.Get(key); !ok || hash != UploadContentHash([]byte("a")) {
+		t.Errorf(assertionTemplate, "reloaded hash", hash, UploadContentHash([]byte("a")))
+	}
+	if _, ok, _ := s.Get(NewUploadStateKey(testProjectID+":feature", "%LANG_ISO%.json", "en")); ok {
+		t.Error("branch shares the hash of the main branch")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version": 99}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenFileUploadState(path); err == nil {
+		t.Error("OpenFileUploadState of an unsupported version returned no error")
+	}
+}